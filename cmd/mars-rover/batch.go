@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/juubisnake/mars-rover/pkg/batch"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// runBatch runs every mission within a directory, or within a stream of missions delimited by
+// blank lines, across a pool of workers.
+func runBatch(args []string) {
+	flags := flag.NewFlagSet("batch", flag.ExitOnError)
+	workers := flags.Int("workers", 0, "the maximum number of missions run at once; defaults to the number of CPUs")
+	simultaneous := flags.Bool("simultaneous", false, "advance every robot by one command per tick rather than moving each robot in turn")
+	setLogger := logFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: mars-rover batch [flags] <directory|missions-file|->\n\nEvery file within a directory is a mission, whereas a missions file or stdin holds\nmany missions, each a block of lines separated from the next by a blank line.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	logger := setLogger()
+
+	missions, err := readMissions(flags.Arg(0))
+	if err != nil {
+		fatal("failed to read missions", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+
+	results, err := batch.Run(ctx, missions, &batch.Options{
+		Workers: *workers,
+		Runner:  &runner.Options{Simultaneous: *simultaneous, Logger: logger},
+	})
+	var failed int
+	for _, r := range results {
+		fmt.Printf("== %s\n", r.Name)
+		if r.Output != "" {
+			fmt.Println(r.Output)
+		}
+		if r.Err != nil {
+			failed++
+			fmt.Printf("error: %v\n", r.Err)
+		}
+	}
+	fmt.Printf("%d missions - %d failed\n", len(results), failed)
+	if err != nil {
+		fatal("batch was stopped", err)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// readMissions reads every file within a directory as a mission, or a stream of missions
+// from a file, or from stdin if the file is '-'.
+func readMissions(path string) ([]batch.Mission, error) {
+	if path == "-" {
+		return batch.ReadStream(os.Stdin)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return batch.ReadDir(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return batch.ReadStream(f)
+}
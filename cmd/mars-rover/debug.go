@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/debugger"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// runDebug loads an instruction-set and steps through it interactively.
+func runDebug(args []string) {
+	flags := flag.NewFlagSet("debug", flag.ExitOnError)
+	simultaneous := flags.Bool("simultaneous", false, "advance every robot by one command per tick rather than moving each robot in turn")
+	setLogger := logFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: mars-rover debug [flags] <instructions-file>\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	logger := setLogger()
+	if flags.Arg(0) == "-" {
+		fatal("failed to read instructions", errors.New("debug reads commands from stdin - the instructions must be read from a file"))
+	}
+	input, err := readInput(flags.Arg(0))
+	if err != nil {
+		fatal("failed to read instructions", err)
+	}
+	mission, err := runner.Simulate(input, &runner.Options{Simultaneous: *simultaneous, Logger: logger})
+	if mission == nil {
+		fatal("failed while running instructions", err)
+	}
+	if err := debugger.New(mission).Run(os.Stdin, os.Stdout); err != nil {
+		fatal("failed while debugging instructions", err)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// logFlags registers the flags that configure logging upon a set of flags, returning a function
+// that sets the default logger once the flags have been parsed.
+func logFlags(flags *flag.FlagSet) func() *slog.Logger {
+	level := flags.String("log-level", "warn", "the minimum level logged: debug, info, warn or error; debug logs every step taken")
+	format := flags.String("log-format", "text", "the format logs are written to stderr in: text or json")
+	return func() *slog.Logger {
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(*level)); err != nil {
+			fmt.Fprintf(flags.Output(), "invalid log level '%s'\n", *level)
+			os.Exit(2)
+		}
+		opts := &slog.HandlerOptions{Level: l}
+		var handler slog.Handler
+		switch *format {
+		case "text":
+			handler = slog.NewTextHandler(os.Stderr, opts)
+		case "json":
+			handler = slog.NewJSONHandler(os.Stderr, opts)
+		default:
+			fmt.Fprintf(flags.Output(), "invalid log format '%s'\n", *format)
+			os.Exit(2)
+		}
+		logger := slog.New(handler)
+		slog.SetDefault(logger)
+		return logger
+	}
+}
+
+// fatal logs a message at error level along with the given error, then exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, runner.ErrorAttr(err))
+	os.Exit(1)
+}
@@ -1,52 +1,215 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"os"
 
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+	"github.com/juubisnake/mars-rover/pkg/export"
+	"github.com/juubisnake/mars-rover/pkg/render"
 	"github.com/juubisnake/mars-rover/pkg/runner"
 )
 
-// main is an example of how to use the mars-rover runner.
+// main runs an instruction-set read from a file, or from stdin when the file is '-'.
+// Running without any arguments will run a set of examples instead.
 func main() {
-	// a typical example of running a single robot across a surface.
-	instructions := `
-5 5
-1 2 N
-LMLMLMLMM
-`
-	result, err := runner.Run(instructions)
+	if len(os.Args) < 2 {
+		runExamples()
+		return
+	}
+	switch os.Args[1] {
+	case "debug":
+		runDebug(os.Args[2:])
+	case "batch":
+		runBatch(os.Args[2:])
+	case "serve":
+		runServe(os.Args[2:])
+	case "replay":
+		runReplay(os.Args[2:])
+	default:
+		runMission(os.Args[1:])
+	}
+}
+
+// runMission runs an instruction-set and outputs the final resting positions of each robot.
+func runMission(args []string) {
+	flags := flag.NewFlagSet("mars-rover", flag.ExitOnError)
+	simultaneous := flags.Bool("simultaneous", false, "advance every robot by one command per tick rather than moving each robot in turn")
+	turnDuration := flags.Duration("turn-duration", runner.DefaultDurations.Turn, "how long each robot takes to turn")
+	moveDuration := flags.Duration("move-duration", runner.DefaultDurations.Move, "how long each robot takes to move")
+	timeLimit := flags.Duration("time-limit", 0, "halt the mission if any robot is unable to finish a command within the limit")
+	timing := flags.Bool("timing", false, "output how long each robot spent moving")
+	renderGrid := flags.Bool("render", false, "render the surface and robots as a text grid")
+	trails := flags.Bool("trails", false, "render the path each robot has taken; requires --render")
+	step := flags.Int("step", -1, "render the mission as it stood after the given number of steps; requires --render")
+	svgFile := flags.String("svg", "", "export an SVG image of each robot's path to the given file")
+	pngFile := flags.String("png", "", "export a PNG image of each robot's path to the given file")
+	setLogger := logFlags(flags)
+	journalFile := flags.String("journal", "", "write every action taken within the mission to the given file as JSON Lines")
+	maskFile := flags.String("mask", "", "read the surface from the given cell mask file, in which case the instructions hold only the robots")
+	maskX := flags.Int("mask-x", 0, "the x-coordinate of the mask's lower-left cell; requires --mask")
+	maskY := flags.Int("mask-y", 0, "the y-coordinate of the mask's lower-left cell; requires --mask")
+	heightmapFile := flags.String("heightmap", "", "read the elevation of the surface from the given text or PGM heightmap file")
+	heightmapX := flags.Int("heightmap-x", 0, "the x-coordinate of the heightmap's lower-left cell; requires --heightmap")
+	heightmapY := flags.Int("heightmap-y", 0, "the y-coordinate of the heightmap's lower-left cell; requires --heightmap")
+	maxClimb := flags.Int("max-climb", 0, "halt the mission if any robot would climb more than the given height in a single move")
+	maxDescent := flags.Int("max-descent", 0, "halt the mission if any robot would descend more than the given height in a single move")
+	speed := flags.Int("speed", 1, "the number of cells each move carries every robot whose type does not give its own step")
+	grid := flags.String("grid", string(runner.SquareGrid), "the grid the surface is made up of; square or hex")
+	stall := flags.Float64("stall", 0, "the probability that each move fails to move the robot")
+	overshoot := flags.Float64("overshoot", 0, "the probability that each move carries the robot one cell too far")
+	drift := flags.Float64("drift", 0, "the probability that each move also carries the robot one cell sideways")
+	overRotate := flags.Float64("over-rotate", 0, "the probability that each turn rotates the robot twice")
+	seed := flags.Uint64("seed", 0, "the seed of the random motion given by --stall, --overshoot, --drift and --over-rotate")
+	runs := flags.Int("runs", 0, "run the mission the given number of times, with seeds from --seed onwards, and output the distribution of each robot's final pose")
+	cameraRange := flags.Int("camera-range", 0, "output how many cells each robot's camera saw, given how many cells away it can see")
+	cameraAngle := flags.Float64("camera-angle", 360, "the width in degrees of the cone each robot's camera can see, centred upon its heading; requires --camera-range")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: mars-rover [flags] <instructions-file|->\n       mars-rover debug [flags] <instructions-file>\n       mars-rover batch [flags] <directory|missions-file|->\n       mars-rover serve [flags]\n       mars-rover replay [flags] <journal-file|->\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	logger := setLogger()
+
+	input, err := readInput(flags.Arg(0))
 	if err != nil {
-		log.Fatalf("failed while running instructions: %v", err)
-	}
-	fmt.Println(result)
-
-	// a example of running a multiple rovers across a surface.
-	instructions = `
-5 5
-1 2 N
-LMLMLMLMM
-3 3 E
-MMRMMRMRRM
-`
-	result, err = runner.Run(instructions)
+		fatal("failed to read instructions", err)
+	}
+	var surface plateau.Surface
+	if *maskFile != "" {
+		if surface, err = readMask(*maskFile, *maskX, *maskY); err != nil {
+			fatal("failed to read mask", err)
+		}
+	}
+	var elevation map[string]*plateau.Heightmap
+	if *heightmapFile != "" {
+		heightmap, err := readHeightmap(*heightmapFile, *heightmapX, *heightmapY)
+		if err != nil {
+			fatal("failed to read heightmap", err)
+		}
+		elevation = map[string]*plateau.Heightmap{"": heightmap}
+	}
+	var journal *runner.Journal
+	if *journalFile != "" {
+		f, err := os.Create(*journalFile)
+		if err != nil {
+			fatal("failed to create journal", err)
+		}
+		defer f.Close()
+		journal = runner.NewJournal(f)
+	}
+	var motion *runner.Motion
+	if *stall > 0 || *overshoot > 0 || *drift > 0 || *overRotate > 0 || *runs > 0 {
+		motion = &runner.Motion{Seed: *seed, Stall: *stall, Overshoot: *overshoot, Drift: *drift, OverRotate: *overRotate}
+	}
+	opts := &runner.Options{
+		Simultaneous: *simultaneous,
+		Durations:    &runner.Durations{Turn: *turnDuration, Move: *moveDuration},
+		TimeLimit:    *timeLimit,
+		Journal:      journal,
+		Logger:       logger,
+		Surface:      surface,
+		Elevation:    elevation,
+		Climb:        &runner.Climb{Up: *maxClimb, Down: *maxDescent},
+		Speed:        *speed,
+		Grid:         runner.Grid(*grid),
+		Motion:       motion,
+	}
+	if *runs > 0 {
+		outcomes, err := runner.MonteCarlo(context.Background(), input, *runs, opts)
+		if err != nil {
+			fatal("failed to run mission", err)
+		}
+		fmt.Println(outcomes)
+		return
+	}
+	mission, err := runner.Simulate(input, opts)
+	if journal != nil && journal.Err() != nil {
+		fatal("failed to write journal", journal.Err())
+	}
+	if mission != nil {
+		if output := mission.String(); output != "" {
+			fmt.Println(output)
+		}
+		if *timing {
+			for _, t := range mission.Tracks {
+				fmt.Printf("robot %d: %v\n", t.ID, t.Duration())
+			}
+			fmt.Printf("mission: %v\n", mission.Duration())
+		}
+		if *cameraRange > 0 {
+			visibility, err := mission.Visibility(runner.Camera{Range: *cameraRange, Angle: *cameraAngle}, nil)
+			if err != nil {
+				fatal("failed to work out visibility", err)
+			}
+			fmt.Println(visibility)
+		}
+		if *renderGrid {
+			fmt.Println(render.Mission(mission.At(*step), &render.Options{Trails: *trails}))
+		}
+		if *svgFile != "" {
+			if err := writeImage(*svgFile, mission, export.SVG); err != nil {
+				fatal("failed to export svg", err)
+			}
+		}
+		if *pngFile != "" {
+			if err := writeImage(*pngFile, mission, export.PNG); err != nil {
+				fatal("failed to export png", err)
+			}
+		}
+	}
 	if err != nil {
-		log.Fatalf("failed while running instructions: %v", err)
-	}
-	fmt.Println(result)
-
-	// a example of outputting the first rovers resting place given
-	// the second rover fails.
-	instructions = `
-5 5
-1 2 N
-LMLMLMLMM
-3 3 E
-MMRMMRMRRMMMMMMMMMM
-`
-	result, err = runner.Run(instructions)
-	if err == nil {
-		log.Fatal("instruction should have failed")
-	}
-	fmt.Println(result)
+		// the runner has already logged the error that halted the mission.
+		os.Exit(1)
+	}
+}
+
+// readInput reads the instruction-set from the given file, or stdin if the file is '-'.
+func readInput(file string) (string, error) {
+	if file == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	}
+	b, err := os.ReadFile(file)
+	return string(b), err
+}
+
+// readMask reads a cell mask from the given file, whose lower-left cell lies at the given coordinates.
+func readMask(file string, lowerX, lowerY int) (*plateau.Mask, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return plateau.ReadMask(f, lowerX, lowerY)
+}
+
+// readHeightmap reads a heightmap from the given file, whose lower-left cell lies at the given coordinates.
+func readHeightmap(file string, lowerX, lowerY int) (*plateau.Heightmap, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return plateau.ReadHeightmap(f, lowerX, lowerY)
+}
+
+// writeImage creates the given file and writes an image of the mission to it.
+func writeImage(file string, mission *runner.Mission, write func(io.Writer, *runner.Mission, *export.Options) error) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := write(f, mission, nil); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/juubisnake/mars-rover/pkg/render"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// runReplay rebuilds a mission from a journal and outputs the final resting positions of each robot.
+func runReplay(args []string) {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	renderGrid := flags.Bool("render", false, "render the surface and robots as a text grid")
+	setLogger := logFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: mars-rover replay [flags] <journal-file|->\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	setLogger()
+	f := os.Stdin
+	if flags.Arg(0) != "-" {
+		var err error
+		if f, err = os.Open(flags.Arg(0)); err != nil {
+			fatal("failed to read journal", err)
+		}
+		defer f.Close()
+	}
+	mission, err := runner.Replay(f)
+	if mission != nil {
+		if output := mission.String(); output != "" {
+			fmt.Println(output)
+		}
+		if *renderGrid {
+			fmt.Println(render.Mission(mission, nil))
+		}
+	}
+	var divergence *runner.ReplayDivergenceError
+	if errors.As(err, &divergence) {
+		fatal("failed to replay journal", err)
+	}
+	if err != nil {
+		fatal("journal recorded an error", err)
+	}
+}
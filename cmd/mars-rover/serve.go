@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/juubisnake/mars-rover/pkg/metrics"
+	"github.com/juubisnake/mars-rover/pkg/rpc"
+	"github.com/juubisnake/mars-rover/pkg/rpc/marsroverpb"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+	"github.com/juubisnake/mars-rover/pkg/server"
+)
+
+// runServe serves an HTTP API, and optionally a gRPC service, that runs missions.
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "the address to serve the HTTP API on")
+	grpcAddr := flags.String("grpc-addr", "", "the address to serve the gRPC service on; empty means the service is not served")
+	timeout := flags.Duration("timeout", 10*time.Second, "cancel any mission that runs for longer; zero means there is no timeout")
+	maxArea := flags.Int("max-area", 0, "the maximum number of cells within a surface; zero means there is no limit")
+	maxRobots := flags.Int("max-robots", 0, "the maximum number of robots within a mission; zero means there is no limit")
+	maxCommands := flags.Int("max-commands", 0, "the maximum number of commands given to a single robot; zero means there is no limit")
+	setLogger := logFlags(flags)
+	maxSteps := flags.Int("max-steps", 0, "the maximum number of steps taken within a mission; zero means there is no limit")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: mars-rover serve [flags]\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	logger := setLogger()
+
+	limits := &runner.Limits{
+		MaxArea:     *maxArea,
+		MaxRobots:   *maxRobots,
+		MaxCommands: *maxCommands,
+		MaxSteps:    *maxSteps,
+	}
+	registry := metrics.New()
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			fatal("failed to listen", err)
+		}
+		s := grpc.NewServer()
+		marsroverpb.RegisterMarsRoverServer(s, rpc.New(&rpc.Options{Timeout: *timeout, Limits: limits, Metrics: registry, Logger: logger}))
+		logger.Info("serving gRPC", "addr", *grpcAddr)
+		go func() {
+			fatal("failed while serving gRPC", s.Serve(lis))
+		}()
+	}
+	handler := server.New(&server.Options{Timeout: *timeout, Limits: limits, Metrics: registry, Logger: logger})
+	logger.Info("serving missions", "addr", *addr)
+	fatal("failed while serving missions", http.ListenAndServe(*addr, handler))
+}
@@ -1,5 +1,20 @@
 module github.com/juubisnake/mars-rover
 
-go 1.15
+// go 1.25.0 is the lowest version allowed here, since google.golang.org/grpc v1.82.1 requires it
+// in its own go.mod and the go command raises this directive to match; the standard library
+// alone would only need go 1.22, for log/slog (1.21) and math/rand/v2 (1.22).
+go 1.25.0
 
-require github.com/pkg/errors v0.9.1
+require (
+	google.golang.org/grpc v1.82.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	go.opentelemetry.io/otel v1.44.0 // indirect
+	go.opentelemetry.io/otel/sdk/metric v1.44.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
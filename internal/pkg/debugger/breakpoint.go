@@ -0,0 +1,54 @@
+package debugger
+
+import "fmt"
+
+// Breakpoint halts the debugger whenever a step that matches it is taken.
+type Breakpoint interface {
+	// Matches checks if the given step should halt the debugger.
+	Matches(s *Step) bool
+	String() string
+}
+
+// RobotBreakpoint halts on every step taken by the robot with the given ID.
+type RobotBreakpoint struct{ ID int }
+
+// Matches checks if the step was taken by the robot.
+func (r *RobotBreakpoint) Matches(s *Step) bool {
+	return s.Track.ID == r.ID
+}
+
+// String outputs a simple representation of the breakpoint.
+func (r *RobotBreakpoint) String() string {
+	return fmt.Sprintf("robot %d", r.ID)
+}
+
+// CommandBreakpoint halts on the step that carries out the command at the given index within
+// a robot's instructions. The index starts at 0 for every robot.
+type CommandBreakpoint struct{ Index int }
+
+// Matches checks if the step carries out the command at the index.
+func (c *CommandBreakpoint) Matches(s *Step) bool {
+	return s.Index == c.Index
+}
+
+// String outputs a simple representation of the breakpoint.
+func (c *CommandBreakpoint) String() string {
+	return fmt.Sprintf("command %d", c.Index)
+}
+
+// CellBreakpoint halts on any step that leaves a robot within the given cell.
+type CellBreakpoint struct {
+	X int
+	Y int
+}
+
+// Matches checks if the step leaves the robot within the cell.
+func (c *CellBreakpoint) Matches(s *Step) bool {
+	p := s.Track.Steps[s.Index].Pose
+	return p.X == c.X && p.Y == c.Y
+}
+
+// String outputs a simple representation of the breakpoint.
+func (c *CellBreakpoint) String() string {
+	return fmt.Sprintf("cell %d %d", c.X, c.Y)
+}
@@ -0,0 +1,274 @@
+package debugger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juubisnake/mars-rover/pkg/render"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// help outlines the commands the debugger understands.
+const help = `commands:
+  n, next [count]        step forward, by one step unless a count is given
+  p, prev [count]        step back, by one step unless a count is given
+  c, continue            step forward until a breakpoint is hit or the mission ends
+  rc, reverse            step back until a breakpoint is hit or the mission starts
+  b, break robot <id>    halt on every step taken by a robot
+  b, break command <i>   halt on the command at index i of any robot
+  b, break cell <x> <y>  halt on any step that leaves a robot at x,y
+  bl, breakpoints        list breakpoints
+  d, delete <n>          delete breakpoint n
+  i, info                show the pose of the current robot
+  r, render              draw the surface
+  h, help                show this message
+  q, quit                exit the debugger`
+
+// Step locates a single step within a mission.
+type Step struct {
+	Track *runner.Track
+	// Index is the index of the step within the track.
+	Index int
+}
+
+// Debugger steps forwards and backwards through a mission one command at a time.
+// A mission is produced via runner.Simulate, so the debugger replays exactly what the runner did.
+type Debugger struct {
+	mission     *runner.Mission
+	step        int
+	breakpoints []Breakpoint
+}
+
+// New creates a debugger positioned at the start of a mission, before any step has been taken.
+func New(mission *runner.Mission) *Debugger {
+	return &Debugger{mission: mission}
+}
+
+// Position returns the number of steps that have been taken.
+func (d *Debugger) Position() int {
+	return d.step
+}
+
+// AddBreakpoint adds a breakpoint that will halt Continue and Reverse.
+func (d *Debugger) AddBreakpoint(b Breakpoint) {
+	d.breakpoints = append(d.breakpoints, b)
+}
+
+// Forward takes up to count steps forward, returning the number of steps taken.
+func (d *Debugger) Forward(count int) int {
+	taken := count
+	if d.step+count > d.mission.Steps() {
+		taken = d.mission.Steps() - d.step
+	}
+	d.step += taken
+	return taken
+}
+
+// Back takes up to count steps back, returning the number of steps taken.
+func (d *Debugger) Back(count int) int {
+	taken := count
+	if d.step-count < 0 {
+		taken = d.step
+	}
+	d.step -= taken
+	return taken
+}
+
+// Continue steps forward until a breakpoint is hit or the mission ends, returning the
+// breakpoint that was hit if any.
+func (d *Debugger) Continue() Breakpoint {
+	for d.Forward(1) == 1 {
+		if b := d.hit(); b != nil {
+			return b
+		}
+	}
+	return nil
+}
+
+// Reverse steps back until a breakpoint is hit or the mission starts, returning the
+// breakpoint that was hit if any.
+func (d *Debugger) Reverse() Breakpoint {
+	for d.Back(1) == 1 {
+		if b := d.hit(); b != nil {
+			return b
+		}
+	}
+	return nil
+}
+
+// Current returns the most recent step taken, or nil if no step has been taken.
+func (d *Debugger) Current() *Step {
+	for _, t := range d.mission.Tracks {
+		i := sort.Search(len(t.Steps), func(i int) bool { return t.Steps[i].Seq >= d.step })
+		if i < len(t.Steps) && t.Steps[i].Seq == d.step {
+			return &Step{Track: t, Index: i}
+		}
+	}
+	return nil
+}
+
+// hit returns the first breakpoint matching the current step.
+func (d *Debugger) hit() Breakpoint {
+	s := d.Current()
+	if s == nil {
+		return nil
+	}
+	for _, b := range d.breakpoints {
+		if b.Matches(s) {
+			return b
+		}
+	}
+	return nil
+}
+
+// Info outputs the pose of the robot that took the current step.
+func (d *Debugger) Info() string {
+	info := fmt.Sprintf("step %d/%d", d.step, d.mission.Steps())
+	s := d.Current()
+	switch {
+	case s != nil:
+		step := s.Track.Steps[s.Index]
+		info += fmt.Sprintf(" | robot %d | command %d '%s' | %s", s.Track.ID, s.Index, step.Command, step.Pose)
+	case len(d.mission.Tracks) > 0:
+		t := d.mission.Tracks[0]
+		info += fmt.Sprintf(" | robot %d | placed | %s", t.ID, t.Start)
+	default:
+		info += " | no robot has been placed"
+	}
+	if d.step == d.mission.Steps() && d.mission.Err != nil {
+		info += fmt.Sprintf("\nmission halted: %v", d.mission.Err)
+	}
+	return info
+}
+
+// Render draws the surface as it stood after the current step.
+func (d *Debugger) Render() string {
+	return render.Mission(d.mission.At(d.step), &render.Options{Trails: true})
+}
+
+// Run reads commands from in and writes their output to out until the user quits
+// or in is exhausted.
+func (d *Debugger) Run(in io.Reader, out io.Writer) error {
+	fmt.Fprintf(out, "%s\n%s\n> ", d.Render(), d.Info())
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			fmt.Fprint(out, "> ")
+			continue
+		}
+		if fields[0] == "q" || fields[0] == "quit" {
+			return nil
+		}
+		fmt.Fprintf(out, "%s\n> ", d.exec(fields))
+	}
+	return scanner.Err()
+}
+
+// exec carries out a single debugger command and returns its output.
+func (d *Debugger) exec(fields []string) string {
+	switch fields[0] {
+	case "n", "next":
+		count, err := parseCount(fields)
+		if err != nil {
+			return err.Error()
+		}
+		d.Forward(count)
+		return d.Render() + "\n" + d.Info()
+	case "p", "prev":
+		count, err := parseCount(fields)
+		if err != nil {
+			return err.Error()
+		}
+		d.Back(count)
+		return d.Render() + "\n" + d.Info()
+	case "c", "continue":
+		return d.halted(d.Continue())
+	case "rc", "reverse":
+		return d.halted(d.Reverse())
+	case "b", "break":
+		b, err := parseBreakpoint(fields[1:])
+		if err != nil {
+			return err.Error()
+		}
+		d.AddBreakpoint(b)
+		return fmt.Sprintf("breakpoint %d: %s", len(d.breakpoints)-1, b)
+	case "bl", "breakpoints":
+		var lines []string
+		for i, b := range d.breakpoints {
+			lines = append(lines, fmt.Sprintf("breakpoint %d: %s", i, b))
+		}
+		if len(lines) == 0 {
+			return "no breakpoints"
+		}
+		return strings.Join(lines, "\n")
+	case "d", "delete":
+		if len(fields) != 2 {
+			return "usage: delete <n>"
+		}
+		i, err := strconv.Atoi(fields[1])
+		if err != nil || i < 0 || i >= len(d.breakpoints) {
+			return fmt.Sprintf("no breakpoint %s", fields[1])
+		}
+		d.breakpoints = append(d.breakpoints[:i], d.breakpoints[i+1:]...)
+		return fmt.Sprintf("deleted breakpoint %d", i)
+	case "i", "info":
+		return d.Info()
+	case "r", "render":
+		return d.Render()
+	case "h", "help":
+		return help
+	default:
+		return fmt.Sprintf("unknown command '%s' - type 'help' for a list of commands", fields[0])
+	}
+}
+
+// halted outputs the state of the debugger after it has been halted by a breakpoint.
+func (d *Debugger) halted(b Breakpoint) string {
+	output := d.Render() + "\n" + d.Info()
+	if b != nil {
+		return fmt.Sprintf("hit breakpoint: %s\n%s", b, output)
+	}
+	return output
+}
+
+// parseCount parses the optional count given to next and prev.
+func parseCount(fields []string) (int, error) {
+	if len(fields) == 1 {
+		return 1, nil
+	}
+	count, err := strconv.Atoi(fields[1])
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("'%s' is not a valid count", fields[1])
+	}
+	return count, nil
+}
+
+// parseBreakpoint parses the arguments given to break.
+func parseBreakpoint(args []string) (Breakpoint, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("usage: break robot <id> | command <i> | cell <x> <y>")
+	}
+	values := make([]int, len(args)-1)
+	for i, a := range args[1:] {
+		v, err := strconv.Atoi(a)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' cannot be transformed into an int", a)
+		}
+		values[i] = v
+	}
+	switch {
+	case args[0] == "robot" && len(values) == 1:
+		return &RobotBreakpoint{ID: values[0]}, nil
+	case args[0] == "command" && len(values) == 1:
+		return &CommandBreakpoint{Index: values[0]}, nil
+	case args[0] == "cell" && len(values) == 2:
+		return &CellBreakpoint{X: values[0], Y: values[1]}, nil
+	default:
+		return nil, fmt.Errorf("usage: break robot <id> | command <i> | cell <x> <y>")
+	}
+}
@@ -0,0 +1,103 @@
+package debugger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+const testInput = `
+5 5
+1 2 N
+LMLMLMLMM
+3 3 E
+MMRMMRMRRMMM`
+
+func testDebugger(t *testing.T) *Debugger {
+	m, _ := runner.Simulate(testInput, nil)
+	if m == nil {
+		t.Fatal("Simulate() should have returned a mission")
+	}
+	return New(m)
+}
+
+func TestDebugger_ForwardBack(t *testing.T) {
+	d := testDebugger(t)
+	if d.Current() != nil {
+		t.Fatal("expected no step to have been taken")
+	}
+	if d.Back(1) != 0 {
+		t.Fatal("expected Back to be unable to move before the start of the mission")
+	}
+	if d.Forward(10) != 10 {
+		t.Fatal("expected Forward to take 10 steps")
+	}
+	s := d.Current()
+	if s.Track.ID != 2 || s.Index != 0 {
+		t.Fatalf("expected step 10 to be the first command of robot ID 2 - got robot ID %d command %d instead", s.Track.ID, s.Index)
+	}
+	if d.Forward(100) != 10 {
+		t.Fatal("expected Forward to stop at the end of the mission")
+	}
+	if d.Back(2) != 2 || d.Position() != 18 {
+		t.Fatalf("expected Back to move to step 18 - got %d instead", d.Position())
+	}
+}
+
+func TestDebugger_Breakpoints(t *testing.T) {
+	d := testDebugger(t)
+	d.AddBreakpoint(&RobotBreakpoint{ID: 2})
+	if b := d.Continue(); b == nil || d.Position() != 10 {
+		t.Fatalf("expected to halt on the first step of robot ID 2 - halted at %d instead", d.Position())
+	}
+	d = testDebugger(t)
+	d.AddBreakpoint(&CommandBreakpoint{Index: 4})
+	d.Continue()
+	if d.Position() != 5 {
+		t.Fatalf("expected to halt on command 4 of robot ID 0 - halted at %d instead", d.Position())
+	}
+	d.Continue()
+	if d.Position() != 14 {
+		t.Fatalf("expected to halt on command 4 of robot ID 2 - halted at %d instead", d.Position())
+	}
+	if b := d.Reverse(); b == nil || d.Position() != 5 {
+		t.Fatalf("expected to reverse to command 4 of robot ID 0 - halted at %d instead", d.Position())
+	}
+	d = testDebugger(t)
+	d.AddBreakpoint(&CellBreakpoint{X: 5, Y: 1})
+	d.Continue()
+	if p := d.Current().Track.Steps[d.Current().Index].Pose; p.X != 5 || p.Y != 1 {
+		t.Fatalf("expected to halt at cell 5 1 - halted at %v instead", p)
+	}
+	for d.Continue() != nil {
+	}
+	if d.Position() != 20 {
+		t.Fatalf("expected to run to the end of the mission - halted at %d instead", d.Position())
+	}
+}
+
+func TestDebugger_Run(t *testing.T) {
+	d := testDebugger(t)
+	in := strings.NewReader("break cell 6 1\ncontinue\ninfo\nprev\nquit\nnext\n")
+	var out bytes.Buffer
+	if err := d.Run(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	output := out.String()
+	for _, expected := range []string{
+		"breakpoint 0: cell 6 1",
+		"hit breakpoint: cell 6 1",
+		"step 20/20 | robot 2 | command 10 'M' | 6 1 E",
+		"mission halted: robot ID 2 has moved out of bounds - X: 6 Y: 1",
+		"step 19/20 | robot 2 | command 9 'M' | 5 1 E",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected debugger output to contain '%s':\n%s", expected, output)
+		}
+	}
+	if d.Position() != 19 {
+		t.Fatalf("expected the debugger to stop reading commands after quit - at step %d instead", d.Position())
+	}
+}
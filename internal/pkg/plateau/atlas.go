@@ -0,0 +1,103 @@
+package plateau
+
+import (
+	"fmt"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// Portal links a cell upon one named surface to an entry cell upon another, such that an object
+// driving onto the cell continues from the entry cell upon the linked surface, facing the given
+// direction.
+type Portal struct {
+	From      string
+	At        Point
+	To        string
+	Entry     Point
+	Direction travel.Direction
+}
+
+// String outputs a simple representation of a given portal.
+func (p Portal) String() string {
+	return fmt.Sprintf("Portal | %s [%d,%d] -> %s [%d,%d] %s", p.From, p.At.X, p.At.Y, p.To, p.Entry.X, p.Entry.Y, p.Direction)
+}
+
+// Atlas is a set of named surfaces linked to one another by portals.
+type Atlas struct {
+	names    []string
+	surfaces map[string]Surface
+	portals  []Portal
+	links    map[string]map[Point]Portal
+}
+
+// NewAtlas creates an empty atlas.
+func NewAtlas() *Atlas {
+	return &Atlas{
+		surfaces: make(map[string]Surface),
+		links:    make(map[string]map[Point]Portal),
+	}
+}
+
+// Add adds a surface to the atlas under a given name.
+// This function will error if the name is empty or already in use.
+func (a *Atlas) Add(name string, s Surface) error {
+	if name == "" {
+		return &AtlasError{Reason: "a surface must be named"}
+	}
+	if _, ok := a.surfaces[name]; ok {
+		return &AtlasError{Reason: fmt.Sprintf("a surface named '%s' has already been added", name)}
+	}
+	a.names = append(a.names, name)
+	a.surfaces[name] = s
+	return nil
+}
+
+// Link adds a portal between two surfaces within the atlas.
+// This function will error if either surface is unknown, if either cell is out of bounds
+// within its surface, or if the cell already holds a portal.
+func (a *Atlas) Link(p Portal) error {
+	from, ok := a.surfaces[p.From]
+	if !ok {
+		return &AtlasError{Reason: fmt.Sprintf("no surface named '%s' has been added", p.From)}
+	}
+	to, ok := a.surfaces[p.To]
+	if !ok {
+		return &AtlasError{Reason: fmt.Sprintf("no surface named '%s' has been added", p.To)}
+	}
+	if from.IsOutOfBounds(p.At.X, p.At.Y) {
+		return &AtlasError{Reason: fmt.Sprintf("portal cell %d,%d is out of bounds within '%s'", p.At.X, p.At.Y, p.From)}
+	}
+	if to.IsOutOfBounds(p.Entry.X, p.Entry.Y) {
+		return &AtlasError{Reason: fmt.Sprintf("entry cell %d,%d is out of bounds within '%s'", p.Entry.X, p.Entry.Y, p.To)}
+	}
+	if _, ok := a.links[p.From][p.At]; ok {
+		return &AtlasError{Reason: fmt.Sprintf("cell %d,%d within '%s' already holds a portal", p.At.X, p.At.Y, p.From)}
+	}
+	if a.links[p.From] == nil {
+		a.links[p.From] = make(map[Point]Portal)
+	}
+	a.links[p.From][p.At] = p
+	a.portals = append(a.portals, p)
+	return nil
+}
+
+// Names returns the name of every surface within the atlas, in the order they were added.
+func (a *Atlas) Names() []string {
+	return append([]string(nil), a.names...)
+}
+
+// Surface returns the surface with the given name, or nil if there is no such surface.
+func (a *Atlas) Surface(name string) Surface {
+	return a.surfaces[name]
+}
+
+// Portal returns the portal upon the given cell of the named surface, if any.
+func (a *Atlas) Portal(name string, x, y int) (Portal, bool) {
+	p, ok := a.links[name][Point{X: x, Y: y}]
+	return p, ok
+}
+
+// Portals returns every portal within the atlas, in the order they were linked.
+func (a *Atlas) Portals() []Portal {
+	return append([]Portal(nil), a.portals...)
+}
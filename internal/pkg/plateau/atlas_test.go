@@ -0,0 +1,46 @@
+package plateau
+
+import (
+	"testing"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+func Test_Atlas_Link(t *testing.T) {
+	a := NewAtlas()
+	alpha, _ := New(3, 3)
+	beta, _ := NewWithLowerBounds(-2, -2, 2, 2)
+	if err := a.Add("alpha", alpha); err != nil {
+		t.Fatalf("Add should have been valid - instead got the following error: %v", err)
+	}
+	if err := a.Add("beta", beta); err != nil {
+		t.Fatalf("Add should have been valid - instead got the following error: %v", err)
+	}
+	if err := a.Add("beta", alpha); err == nil {
+		t.Fatal("Add should have failed for a name already in use")
+	}
+	p := Portal{From: "alpha", At: Point{X: 3, Y: 1}, To: "beta", Entry: Point{X: -2, Y: 0}, Direction: travel.East}
+	if err := a.Link(p); err != nil {
+		t.Fatalf("Link should have been valid - instead got the following error: %v", err)
+	}
+	if got, ok := a.Portal("alpha", 3, 1); !ok || got != p {
+		t.Fatalf("expected portal %v - got %v instead", p, got)
+	}
+	if _, ok := a.Portal("beta", -2, 0); ok {
+		t.Fatal("portals should only link one way")
+	}
+	for _, invalid := range []Portal{
+		p,
+		{From: "gamma", To: "beta"},
+		{From: "alpha", To: "gamma"},
+		{From: "alpha", At: Point{X: 4, Y: 1}, To: "beta"},
+		{From: "alpha", To: "beta", Entry: Point{X: 3, Y: 0}},
+	} {
+		if _, ok := a.Link(invalid).(*AtlasError); !ok {
+			t.Fatalf("expected Link to fail with an AtlasError for %v", invalid)
+		}
+	}
+	if names := a.Names(); len(names) != 2 || names[0] != "alpha" || names[1] != "beta" {
+		t.Fatalf("expected names in the order they were added - got %v instead", names)
+	}
+}
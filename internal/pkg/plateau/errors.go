@@ -3,8 +3,8 @@ package plateau
 import "fmt"
 
 // UpperBoundsError is an error that is returned whenever the plateau surface's upper boundaries
-// are less than the lower boundaries defined within the plateau package; see plateau.lowerBoundX
-// and plateau.lowerBoundY
+// are less than its lower boundaries; these are either given to NewWithLowerBounds or default to
+// those defined within the plateau package; see plateau.lowerBoundX and plateau.lowerBoundY
 type UpperBoundsError struct {
 	Coordinate string
 	Value      int
@@ -15,3 +15,55 @@ type UpperBoundsError struct {
 func (o *UpperBoundsError) Error() string {
 	return fmt.Sprintf("the upper bound %d for coordinate %s must be greater than or equal to %d", o.Value, o.Coordinate, o.LowerBound)
 }
+
+// PolygonError is an error that is returned whenever a polygon is given too few vertices to
+// enclose a surface.
+type PolygonError struct {
+	Vertices int
+}
+
+// Error returns a message containing the number of vertices given to the polygon.
+func (p *PolygonError) Error() string {
+	return fmt.Sprintf("a polygon requires at least %d vertices - %d were given", minimumPolygonVertices, p.Vertices)
+}
+
+// MaskError is an error that is returned whenever a cell mask does not describe a surface.
+type MaskError struct {
+	Reason string
+}
+
+// Error returns a message containing why the mask is invalid.
+func (m *MaskError) Error() string {
+	return fmt.Sprintf("invalid cell mask: %s", m.Reason)
+}
+
+// AtlasError is an error that is returned whenever a surface or portal cannot be added to an atlas.
+type AtlasError struct {
+	Reason string
+}
+
+// Error returns a message containing why the surface or portal is invalid.
+func (a *AtlasError) Error() string {
+	return fmt.Sprintf("invalid atlas: %s", a.Reason)
+}
+
+// HeightmapError is an error that is returned whenever a heightmap cannot be read or does not
+// give the elevation of any cell.
+type HeightmapError struct {
+	Reason string
+}
+
+// Error returns a message containing why the heightmap is invalid.
+func (h *HeightmapError) Error() string {
+	return fmt.Sprintf("invalid heightmap: %s", h.Reason)
+}
+
+// HexagonError is an error that is returned whenever a hexagon is given a negative radius.
+type HexagonError struct {
+	Radius int
+}
+
+// Error returns a message containing the radius given to the hexagon.
+func (h *HexagonError) Error() string {
+	return fmt.Sprintf("a hexagon requires a radius of at least 0 - %d was given", h.Radius)
+}
@@ -0,0 +1,204 @@
+package plateau
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// asciiPGM is the magic number that begins a plain PGM image, whose pixels are written as text.
+	asciiPGM = "P2"
+	// binaryPGM is the magic number that begins a raw PGM image, whose pixels are written as bytes.
+	binaryPGM = "P5"
+)
+
+// Heightmap is the elevation of each cell within a rectangle, described by rows of heights.
+// The first row is the top of the rectangle, so that the y-axis increases upward, and the first
+// height of the last row is its lower-left coordinate. Any cell outside of the rectangle has
+// an elevation of zero.
+type Heightmap struct {
+	rows   [][]int
+	bounds *Rectangle
+}
+
+// NewHeightmap creates a new Heightmap from the given rows, with the lower-left coordinate given
+// by lowerX and lowerY.
+// This function will error if no rows are given or the rows differ in length.
+func NewHeightmap(lowerX, lowerY int, rows [][]int) (*Heightmap, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, &HeightmapError{Reason: "no heights were given"}
+	}
+	copied := make([][]int, len(rows))
+	for i, r := range rows {
+		if len(r) != len(rows[0]) {
+			return nil, &HeightmapError{Reason: fmt.Sprintf("row %d has %d heights, expected %d", i, len(r), len(rows[0]))}
+		}
+		copied[i] = append([]int(nil), r...)
+	}
+	return &Heightmap{
+		rows: copied,
+		bounds: &Rectangle{
+			LowerBoundX: lowerX,
+			LowerBoundY: lowerY,
+			UpperBoundX: lowerX + len(rows[0]) - 1,
+			UpperBoundY: lowerY + len(rows) - 1,
+		},
+	}, nil
+}
+
+// ReadHeightmap reads the rows of a Heightmap, with the lower-left coordinate given by lowerX
+// and lowerY, from either a PGM image, whose grey level gives the height of each pixel, or a
+// text grid holding one row per line of heights delimited by whitespace. Blank lines within a
+// text grid are ignored.
+func ReadHeightmap(r io.Reader, lowerX, lowerY int) (*Heightmap, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(asciiPGM))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	var rows [][]int
+	switch string(magic) {
+	case asciiPGM, binaryPGM:
+		rows, err = readPGM(br)
+	default:
+		rows, err = readHeights(br)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewHeightmap(lowerX, lowerY, rows)
+}
+
+// readHeights reads the rows of a text grid.
+func readHeights(r io.Reader) ([][]int, error) {
+	var rows [][]int
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		row := make([]int, len(fields))
+		for i, f := range fields {
+			height, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, &HeightmapError{Reason: fmt.Sprintf("height '%s' in row %d cannot be transformed into an int", f, len(rows))}
+			}
+			row[i] = height
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// readPGM reads the rows of a plain or raw PGM image.
+func readPGM(r *bufio.Reader) ([][]int, error) {
+	var header [4]string
+	for i := range header {
+		token, err := pgmToken(r)
+		if err != nil {
+			return nil, &HeightmapError{Reason: fmt.Sprintf("incomplete PGM header: %v", err)}
+		}
+		header[i] = token
+	}
+	var size [3]int
+	for i, token := range header[1:] {
+		value, err := strconv.Atoi(token)
+		if err != nil || value <= 0 {
+			return nil, &HeightmapError{Reason: fmt.Sprintf("PGM header value '%s' must be a positive int", token)}
+		}
+		size[i] = value
+	}
+	width, height, maxGrey := size[0], size[1], size[2]
+	rows := make([][]int, height)
+	for y := range rows {
+		rows[y] = make([]int, width)
+		for x := range rows[y] {
+			var grey int
+			var err error
+			if header[0] == binaryPGM {
+				grey, err = pgmByte(r, maxGrey)
+			} else {
+				var token string
+				if token, err = pgmToken(r); err == nil {
+					grey, err = strconv.Atoi(token)
+				}
+			}
+			if err != nil {
+				return nil, &HeightmapError{Reason: fmt.Sprintf("PGM pixel %d,%d cannot be read: %v", x, y, err)}
+			}
+			rows[y][x] = grey
+		}
+	}
+	return rows, nil
+}
+
+// pgmToken reads the next token delimited by whitespace within a PGM image, skipping any
+// comment beginning with '#'. The whitespace following the token is consumed.
+func pgmToken(r *bufio.Reader) (string, error) {
+	var token bytes.Buffer
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF && token.Len() > 0 {
+			return token.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case c == '#' && token.Len() == 0:
+			if _, err := r.ReadString('\n'); err != nil {
+				return "", err
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if token.Len() > 0 {
+				return token.String(), nil
+			}
+		default:
+			token.WriteByte(c)
+		}
+	}
+}
+
+// pgmByte reads a single pixel of a raw PGM image, which takes two bytes whenever the maximum
+// grey level is greater than 255.
+func pgmByte(r *bufio.Reader, maxGrey int) (int, error) {
+	hi, err := r.ReadByte()
+	if err != nil || maxGrey < 256 {
+		return int(hi), err
+	}
+	lo, err := r.ReadByte()
+	return int(hi)<<8 | int(lo), err
+}
+
+// Height returns the elevation of a cell, which is zero for any cell outside of the heightmap.
+func (h *Heightmap) Height(x, y int) int {
+	if h.bounds.IsOutOfBounds(x, y) {
+		return 0
+	}
+	return h.rows[h.bounds.UpperBoundY-y][x-h.bounds.LowerBoundX]
+}
+
+// Rows returns the rows of the heightmap, starting with the top of the rectangle.
+func (h *Heightmap) Rows() [][]int {
+	rows := make([][]int, len(h.rows))
+	for i, r := range h.rows {
+		rows[i] = append([]int(nil), r...)
+	}
+	return rows
+}
+
+// Bounds returns the rectangle the heightmap gives the elevation of.
+func (h *Heightmap) Bounds() *Rectangle {
+	return h.bounds
+}
+
+// String outputs a simple representation of a given heightmap.
+func (h *Heightmap) String() string {
+	b := h.bounds
+	return fmt.Sprintf("Heightmap | lower-bounds [%d,%d] - upper-bounds [%d,%d]", b.LowerBoundX, b.LowerBoundY, b.UpperBoundX, b.UpperBoundY)
+}
@@ -0,0 +1,42 @@
+package plateau
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_NewHeightmap_Invalid(t *testing.T) {
+	if _, err := NewHeightmap(0, 0, nil); err == nil {
+		t.Fatal("NewHeightmap should have failed without any rows")
+	}
+	_, err := NewHeightmap(0, 0, [][]int{{1, 2}, {3}})
+	if _, ok := err.(*HeightmapError); !ok {
+		t.Fatalf("expected err to be HeightmapError - got %T instead", err)
+	}
+}
+
+func Test_ReadHeightmap(t *testing.T) {
+	expected := [][]int{{0, 1, 2}, {3, 4, 300}}
+	for name, input := range map[string]string{
+		"text":  "0 1 2\r\n\n3  4\t300\n",
+		"plain": "P2\n# a comment\n3 2\n300\n0 1 2\n3 4 300\n",
+		"raw":   "P5 3 2 300\n\x00\x00\x00\x01\x00\x02\x00\x03\x00\x04\x01\x2c",
+	} {
+		h, err := ReadHeightmap(strings.NewReader(input), -1, 0)
+		if err != nil {
+			t.Fatalf("ReadHeightmap should have read the %s heightmap - instead got the following error: %v", name, err)
+		}
+		if !reflect.DeepEqual(h.Rows(), expected) {
+			t.Fatalf("expected the %s heightmap to hold %v - got %v instead", name, expected, h.Rows())
+		}
+		if h.Height(-1, 1) != 0 || h.Height(1, 0) != 300 || h.Height(0, 0) != 4 || h.Height(2, 0) != 0 {
+			t.Fatalf("expected the %s heightmap's top row to be the highest y", name)
+		}
+	}
+	for _, input := range []string{"", "1 A", "P2 3 2 255\n0 1", "P5 0 2 255\n"} {
+		if _, err := ReadHeightmap(strings.NewReader(input), 0, 0); err == nil {
+			t.Fatalf("ReadHeightmap should have failed for %q", input)
+		}
+	}
+}
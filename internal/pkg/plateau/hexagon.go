@@ -0,0 +1,64 @@
+package plateau
+
+import "fmt"
+
+// Hexagon is a hex-shaped surface upon a hex grid using axial coordinates, made up of every hex
+// within a given distance of its centre.
+type Hexagon struct {
+	centre Point
+	radius int
+	bounds *Rectangle
+}
+
+// NewHexagon creates a new Hexagon centred upon the given coordinate, made up of every hex that
+// is no more than radius hexes away from it.
+// This function will error if the radius is negative.
+func NewHexagon(centreX, centreY, radius int) (*Hexagon, error) {
+	if radius < 0 {
+		return nil, &HexagonError{Radius: radius}
+	}
+	return &Hexagon{
+		centre: Point{X: centreX, Y: centreY},
+		radius: radius,
+		bounds: &Rectangle{
+			LowerBoundX: centreX - radius,
+			LowerBoundY: centreY - radius,
+			UpperBoundX: centreX + radius,
+			UpperBoundY: centreY + radius,
+		},
+	}, nil
+}
+
+// Centre returns the hex at the centre of the hexagon.
+func (h *Hexagon) Centre() Point {
+	return h.centre
+}
+
+// Radius returns the greatest distance of any hex within the hexagon from its centre.
+func (h *Hexagon) Radius() int {
+	return h.radius
+}
+
+// IsOutOfBounds checks if a coordinate is further than the radius from the hexagon's centre.
+func (h *Hexagon) IsOutOfBounds(x, y int) bool {
+	dx, dy := x-h.centre.X, y-h.centre.Y
+	return (abs(dx)+abs(dy)+abs(dx+dy))/2 > h.radius
+}
+
+// Bounds returns the smallest rectangle of axial coordinates containing the hexagon.
+func (h *Hexagon) Bounds() *Rectangle {
+	return h.bounds
+}
+
+// String outputs a simple representation of a given hexagon.
+func (h *Hexagon) String() string {
+	return fmt.Sprintf("Surface | hexagon centre [%d,%d] - radius %d", h.centre.X, h.centre.Y, h.radius)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,27 @@
+package plateau
+
+import "testing"
+
+func Test_NewHexagon_Invalid(t *testing.T) {
+	_, err := NewHexagon(0, 0, -1)
+	if _, ok := err.(*HexagonError); !ok {
+		t.Fatalf("expected err to be HexagonError - got %T instead", err)
+	}
+}
+
+func Test_Hexagon_IsOutOfBounds(t *testing.T) {
+	h, err := NewHexagon(1, -1, 1)
+	if err != nil {
+		t.Fatalf("NewHexagon should have been valid - instead got the following error: %v", err)
+	}
+	for _, c := range []Point{{1, -1}, {2, -1}, {0, -1}, {1, 0}, {0, 0}, {2, -2}, {1, -2}} {
+		if h.IsOutOfBounds(c.X, c.Y) {
+			t.Fatalf("x=%d y=%d should not have been out-of-bounds", c.X, c.Y)
+		}
+	}
+	for _, c := range []Point{{2, 0}, {0, -2}, {3, -1}, {1, 1}} {
+		if !h.IsOutOfBounds(c.X, c.Y) {
+			t.Fatalf("x=%d y=%d should have been out-of-bounds", c.X, c.Y)
+		}
+	}
+}
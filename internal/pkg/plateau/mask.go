@@ -0,0 +1,91 @@
+package plateau
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maskCell marks a cell that is part of the surface within a cell mask; every other character
+// marks a cell that is not.
+const maskCell = '.'
+
+// Mask is a surface made up of an explicit set of cells, described by rows of characters where
+// a '.' is a cell within the surface. The first row is the top of the surface, so that the y-axis
+// increases upward, and the first character of the last row is its lower-left coordinate.
+type Mask struct {
+	rows   []string
+	bounds *Rectangle
+}
+
+// NewMask creates a new Mask from the given rows, with the lower-left coordinate given by
+// lowerX and lowerY. Rows may differ in length; any cell missing from a row is not part of
+// the surface.
+// This function will error if no rows are given or no cell is part of the surface.
+func NewMask(lowerX, lowerY int, rows []string) (*Mask, error) {
+	if len(rows) == 0 {
+		return nil, &MaskError{Reason: "no rows were given"}
+	}
+	var width int
+	var cells bool
+	for _, r := range rows {
+		width = max(width, len(r))
+		cells = cells || strings.IndexByte(r, maskCell) >= 0
+	}
+	if !cells {
+		return nil, &MaskError{Reason: fmt.Sprintf("no cell is marked with '%c'", maskCell)}
+	}
+	return &Mask{
+		rows: append([]string(nil), rows...),
+		bounds: &Rectangle{
+			LowerBoundX: lowerX,
+			LowerBoundY: lowerY,
+			UpperBoundX: lowerX + width - 1,
+			UpperBoundY: lowerY + len(rows) - 1,
+		},
+	}, nil
+}
+
+// ReadMask reads the rows of a Mask from an ASCII map, one row per line, with the lower-left
+// coordinate given by lowerX and lowerY. Trailing blank lines are ignored.
+func ReadMask(r io.Reader, lowerX, lowerY int) (*Mask, error) {
+	var rows []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		rows = append(rows, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for len(rows) > 0 && strings.TrimSpace(rows[len(rows)-1]) == "" {
+		rows = rows[:len(rows)-1]
+	}
+	return NewMask(lowerX, lowerY, rows)
+}
+
+// Rows returns the rows of the mask, starting with the top of the surface.
+func (m *Mask) Rows() []string {
+	return append([]string(nil), m.rows...)
+}
+
+// IsOutOfBounds checks if a coordinate is not one of the mask's cells.
+func (m *Mask) IsOutOfBounds(x, y int) bool {
+	if m.bounds.IsOutOfBounds(x, y) {
+		return true
+	}
+	row := m.rows[m.bounds.UpperBoundY-y]
+	col := x - m.bounds.LowerBoundX
+	return col >= len(row) || row[col] != maskCell
+}
+
+// Bounds returns the smallest rectangle containing every row of the mask.
+func (m *Mask) Bounds() *Rectangle {
+	return m.bounds
+}
+
+// String outputs a simple representation of a given mask.
+func (m *Mask) String() string {
+	b := m.bounds
+	return fmt.Sprintf("Surface | mask lower-bounds [%d,%d] - upper-bounds [%d,%d]", b.LowerBoundX, b.LowerBoundY, b.UpperBoundX, b.UpperBoundY)
+}
@@ -0,0 +1,37 @@
+package plateau
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_NewMask_Invalid(t *testing.T) {
+	if _, err := NewMask(0, 0, nil); err == nil {
+		t.Fatal("NewMask should have failed without any rows")
+	}
+	_, err := NewMask(0, 0, []string{"##", "# "})
+	if _, ok := err.(*MaskError); !ok {
+		t.Fatalf("expected err to be MaskError - got %T instead", err)
+	}
+}
+
+func Test_ReadMask_IsOutOfBounds(t *testing.T) {
+	m, err := ReadMask(strings.NewReader("..#\r\n.\n...\n\n"), -1, 0)
+	if err != nil {
+		t.Fatalf("ReadMask should have been valid - instead got the following error: %v", err)
+	}
+	b := m.Bounds()
+	if b.LowerBoundX != -1 || b.LowerBoundY != 0 || b.UpperBoundX != 1 || b.UpperBoundY != 2 {
+		t.Fatalf("Bounds should have enclosed every row - instead got: %v", b)
+	}
+	for _, c := range []Point{{-1, 2}, {0, 2}, {-1, 1}, {-1, 0}, {1, 0}} {
+		if m.IsOutOfBounds(c.X, c.Y) {
+			t.Fatalf("x=%d y=%d should not have been out-of-bounds", c.X, c.Y)
+		}
+	}
+	for _, c := range []Point{{1, 2}, {0, 1}, {1, 1}, {2, 0}, {-1, 3}, {-2, 0}} {
+		if !m.IsOutOfBounds(c.X, c.Y) {
+			t.Fatalf("x=%d y=%d should have been out-of-bounds", c.X, c.Y)
+		}
+	}
+}
@@ -10,38 +10,60 @@ const (
 )
 
 // Surface is a representation of a plateau upon which objects can traverse.
-type Surface struct {
+type Surface interface {
+	// IsOutOfBounds checks if a coordinate is out of bounds within the surface.
+	IsOutOfBounds(x, y int) bool
+	// Bounds returns the smallest rectangle containing every coordinate within the surface.
+	Bounds() *Rectangle
+	// String outputs a simple representation of the surface.
+	String() string
+}
+
+// Rectangle is a rectangular surface, bounded by its lower-left and upper-right coordinates.
+type Rectangle struct {
 	LowerBoundX int
 	LowerBoundY int
 	UpperBoundX int
 	UpperBoundY int
 }
 
-// New creates a new Surface with a given upper-right boundary, represented via x and y coordinates,
+// New creates a new Rectangle with a given upper-right boundary, represented via x and y coordinates,
 // with a lower-left boundary of 0,0.
 // This function will error if any of the upper-right boundaries are less than the lower-left ones.
-func New(upperBoundX, upperBoundY int) (*Surface, error) {
-	if upperBoundX < lowerBoundX {
-		return nil, &UpperBoundsError{Coordinate: "x", Value: upperBoundX, LowerBound: lowerBoundX}
+func New(upperBoundX, upperBoundY int) (*Rectangle, error) {
+	return NewWithLowerBounds(lowerBoundX, lowerBoundY, upperBoundX, upperBoundY)
+}
+
+// NewWithLowerBounds creates a new Rectangle with a given lower-left and upper-right boundary, each
+// represented via x and y coordinates, allowing for surfaces whose coordinates are negative.
+// This function will error if any of the upper-right boundaries are less than the lower-left ones.
+func NewWithLowerBounds(lowerX, lowerY, upperX, upperY int) (*Rectangle, error) {
+	if upperX < lowerX {
+		return nil, &UpperBoundsError{Coordinate: "x", Value: upperX, LowerBound: lowerX}
 	}
-	if upperBoundY < lowerBoundY {
-		return nil, &UpperBoundsError{Coordinate: "y", Value: upperBoundY, LowerBound: lowerBoundY}
+	if upperY < lowerY {
+		return nil, &UpperBoundsError{Coordinate: "y", Value: upperY, LowerBound: lowerY}
 	}
-	return &Surface{
-		LowerBoundX: lowerBoundX,
-		LowerBoundY: lowerBoundY,
-		UpperBoundX: upperBoundX,
-		UpperBoundY: upperBoundY,
+	return &Rectangle{
+		LowerBoundX: lowerX,
+		LowerBoundY: lowerY,
+		UpperBoundX: upperX,
+		UpperBoundY: upperY,
 	}, nil
 }
 
 // IsOutOfBounds checks if a coordinate is out of bounds within a given surface.
-func (s *Surface) IsOutOfBounds(x, y int) bool {
+func (s *Rectangle) IsOutOfBounds(x, y int) bool {
 	return checkOutOfBounds(x, s.UpperBoundX, s.LowerBoundX) || checkOutOfBounds(y, s.UpperBoundY, s.LowerBoundY)
 }
 
+// Bounds returns the rectangle itself.
+func (s *Rectangle) Bounds() *Rectangle {
+	return s
+}
+
 // String outputs a simple representation of a given surface.
-func (s *Surface) String() string {
+func (s *Rectangle) String() string {
 	return fmt.Sprintf("Surface | lower-bounds [%d,%d] - upper-bounds [%d,%d]", s.LowerBoundX, s.LowerBoundY, s.UpperBoundX, s.UpperBoundY)
 }
 
@@ -66,3 +66,27 @@ func Test_IsOutOfBounds(t *testing.T) {
 		t.Fatalf("x=%d y=%d should not have been out-of-bounds", x, y)
 	}
 }
+
+func Test_NewWithLowerBounds_InvalidUpperBound(t *testing.T) {
+	_, err := NewWithLowerBounds(-5, -5, 5, -6)
+	ue, ok := err.(*UpperBoundsError)
+	if !ok {
+		t.Fatalf("expected err to be UpperBoundsError - got %T instead", err)
+	}
+	if ue.Coordinate != "y" || ue.Value != -6 || ue.LowerBound != -5 {
+		t.Fatalf("expected error to fail on y coordinate of -6 which is less then lower bound for y -5 - instead got: %v", err)
+	}
+}
+
+func Test_NewWithLowerBounds_IsOutOfBounds(t *testing.T) {
+	s, err := NewWithLowerBounds(-5, -3, 5, 3)
+	if err != nil {
+		t.Fatalf("NewWithLowerBounds should have been valid with bounds -5,-3 and 5,3 - instead got the following error: %v", err)
+	}
+	if s.IsOutOfBounds(-5, -3) || s.IsOutOfBounds(5, 3) || s.IsOutOfBounds(0, 0) {
+		t.Fatal("coordinates within the bounds should not have been out-of-bounds")
+	}
+	if !s.IsOutOfBounds(-6, 0) || !s.IsOutOfBounds(0, -4) || !s.IsOutOfBounds(6, 0) || !s.IsOutOfBounds(0, 4) {
+		t.Fatal("coordinates beyond the bounds should have been out-of-bounds")
+	}
+}
@@ -0,0 +1,92 @@
+package plateau
+
+import (
+	"fmt"
+	"strings"
+)
+
+// minimumPolygonVertices is the fewest vertices needed for a polygon to enclose a surface.
+const minimumPolygonVertices = 3
+
+// Point is a coordinate within a surface.
+type Point struct {
+	X int
+	Y int
+}
+
+// Polygon is a surface bounded by a polygon, whose vertices are given in order around its
+// boundary. A coordinate is within the surface if it lies inside the polygon or upon its boundary.
+type Polygon struct {
+	vertices []Point
+	bounds   *Rectangle
+}
+
+// NewPolygon creates a new Polygon with the given vertices.
+// This function will error if fewer than three vertices are given.
+func NewPolygon(vertices []Point) (*Polygon, error) {
+	if len(vertices) < minimumPolygonVertices {
+		return nil, &PolygonError{Vertices: len(vertices)}
+	}
+	bounds := &Rectangle{
+		LowerBoundX: vertices[0].X,
+		LowerBoundY: vertices[0].Y,
+		UpperBoundX: vertices[0].X,
+		UpperBoundY: vertices[0].Y,
+	}
+	for _, v := range vertices[1:] {
+		bounds.LowerBoundX = min(bounds.LowerBoundX, v.X)
+		bounds.LowerBoundY = min(bounds.LowerBoundY, v.Y)
+		bounds.UpperBoundX = max(bounds.UpperBoundX, v.X)
+		bounds.UpperBoundY = max(bounds.UpperBoundY, v.Y)
+	}
+	return &Polygon{vertices: append([]Point(nil), vertices...), bounds: bounds}, nil
+}
+
+// Vertices returns the vertices of the polygon in order around its boundary.
+func (p *Polygon) Vertices() []Point {
+	return append([]Point(nil), p.vertices...)
+}
+
+// IsOutOfBounds checks if a coordinate lies outside of the polygon.
+func (p *Polygon) IsOutOfBounds(x, y int) bool {
+	if p.bounds.IsOutOfBounds(x, y) {
+		return true
+	}
+	inside := false
+	for i, a := range p.vertices {
+		b := p.vertices[(i+1)%len(p.vertices)]
+		if onSegment(a, b, x, y) {
+			return false
+		}
+		// count the edges crossed by a ray cast from the coordinate towards positive x.
+		if (a.Y > y) != (b.Y > y) {
+			crossing := float64(a.X) + float64(y-a.Y)*float64(b.X-a.X)/float64(b.Y-a.Y)
+			if float64(x) < crossing {
+				inside = !inside
+			}
+		}
+	}
+	return !inside
+}
+
+// Bounds returns the smallest rectangle containing every vertex of the polygon.
+func (p *Polygon) Bounds() *Rectangle {
+	return p.bounds
+}
+
+// String outputs a simple representation of a given polygon.
+func (p *Polygon) String() string {
+	vertices := make([]string, len(p.vertices))
+	for i, v := range p.vertices {
+		vertices[i] = fmt.Sprintf("[%d,%d]", v.X, v.Y)
+	}
+	return fmt.Sprintf("Surface | polygon %s", strings.Join(vertices, " "))
+}
+
+// onSegment checks if a coordinate lies upon the line segment between a and b.
+func onSegment(a, b Point, x, y int) bool {
+	if (b.X-a.X)*(y-a.Y)-(b.Y-a.Y)*(x-a.X) != 0 {
+		return false
+	}
+	return x >= min(a.X, b.X) && x <= max(a.X, b.X) && y >= min(a.Y, b.Y) && y <= max(a.Y, b.Y)
+}
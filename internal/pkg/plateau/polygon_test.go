@@ -0,0 +1,49 @@
+package plateau
+
+import "testing"
+
+func Test_NewPolygon_TooFewVertices(t *testing.T) {
+	_, err := NewPolygon([]Point{{0, 0}, {5, 5}})
+	pe, ok := err.(*PolygonError)
+	if !ok {
+		t.Fatalf("expected err to be PolygonError - got %T instead", err)
+	}
+	if pe.Vertices != 2 {
+		t.Fatalf("expected error to report 2 vertices - instead got: %v", err)
+	}
+}
+
+func Test_Polygon_IsOutOfBounds(t *testing.T) {
+	// an L-shaped surface, missing its upper-right quarter.
+	p, err := NewPolygon([]Point{{0, 0}, {4, 0}, {4, 2}, {2, 2}, {2, 4}, {0, 4}})
+	if err != nil {
+		t.Fatalf("NewPolygon should have been valid - instead got the following error: %v", err)
+	}
+	for _, c := range []Point{{0, 0}, {4, 0}, {4, 2}, {3, 2}, {2, 3}, {1, 1}, {0, 4}, {2, 2}} {
+		if p.IsOutOfBounds(c.X, c.Y) {
+			t.Fatalf("x=%d y=%d should not have been out-of-bounds", c.X, c.Y)
+		}
+	}
+	for _, c := range []Point{{3, 3}, {4, 4}, {5, 0}, {-1, 2}, {0, 5}} {
+		if !p.IsOutOfBounds(c.X, c.Y) {
+			t.Fatalf("x=%d y=%d should have been out-of-bounds", c.X, c.Y)
+		}
+	}
+	b := p.Bounds()
+	if b.LowerBoundX != 0 || b.LowerBoundY != 0 || b.UpperBoundX != 4 || b.UpperBoundY != 4 {
+		t.Fatalf("Bounds should have enclosed every vertex - instead got: %v", b)
+	}
+}
+
+func Test_Polygon_Diagonal(t *testing.T) {
+	p, err := NewPolygon([]Point{{0, 0}, {4, 0}, {0, 4}})
+	if err != nil {
+		t.Fatalf("NewPolygon should have been valid - instead got the following error: %v", err)
+	}
+	if p.IsOutOfBounds(2, 2) || p.IsOutOfBounds(1, 2) {
+		t.Fatal("coordinates upon or within the hypotenuse should not have been out-of-bounds")
+	}
+	if !p.IsOutOfBounds(3, 2) {
+		t.Fatal("x=3 y=2 lies beyond the hypotenuse and should have been out-of-bounds")
+	}
+}
@@ -9,9 +9,11 @@ import (
 // Robot is a representation of a robot that is capable of moving across a surface.
 type Robot struct {
 	id        int
+	plateau   string
 	positionX int
 	positionY int
 	direction travel.Direction
+	kind      *Type
 }
 
 // New creates an instance of a robot that holds information of where it is positioned
@@ -25,11 +27,35 @@ func New(id, x, y int, direction travel.Direction) *Robot {
 	}
 }
 
+// NewOn creates an instance of a robot in the same way as New, but positioned upon the
+// named plateau of a mission that spans more than one.
+func NewOn(id int, plateau string, x, y int, direction travel.Direction) *Robot {
+	r := New(id, x, y, direction)
+	r.plateau = plateau
+	return r
+}
+
 // GetID returns the ID of the given robot.
 func (r *Robot) GetID() int {
 	return r.id
 }
 
+// GetPlateau returns the name of the plateau the given robot is positioned upon, which
+// is empty unless the robot was created using NewOn.
+func (r *Robot) GetPlateau() string {
+	return r.plateau
+}
+
+// GetType returns the type of the given robot, which is nil unless one has been set using SetType.
+func (r *Robot) GetType() *Type {
+	return r.kind
+}
+
+// SetType sets the type of the given robot, which determines what it is capable of.
+func (r *Robot) SetType(t *Type) {
+	r.kind = t
+}
+
 // GetX returns the x coordinate of the given robot.
 func (r *Robot) GetX() int {
 	return r.positionX
@@ -55,8 +81,20 @@ func (r *Robot) Move(x, y int, direction travel.Direction) {
 	r.direction = direction
 }
 
-// String is a representation of a given robot in the form of X Y DIRECTION.
+// Transfer repositions the robot onto the named plateau at the given coordinates and direction.
+func (r *Robot) Transfer(plateau string, x, y int, direction travel.Direction) {
+	r.plateau = plateau
+	r.positionX = x
+	r.positionY = y
+	r.direction = direction
+}
+
+// String is a representation of a given robot in the form of X Y DIRECTION, preceded by
+// the name of its plateau whenever it has one.
 // I.E A robot that has x=2 y=4 direction=west will output 2 4 W.
 func (r *Robot) String() string {
+	if r.plateau != "" {
+		return fmt.Sprintf("%s %d %d %s", r.plateau, r.positionX, r.positionY, r.direction)
+	}
 	return fmt.Sprintf("%d %d %s", r.positionX, r.positionY, r.direction)
 }
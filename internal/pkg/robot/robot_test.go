@@ -57,3 +57,27 @@ func Test_String(t *testing.T) {
 		t.Fatalf("expected String to have produced %s - instead got %s", expected, r.String())
 	}
 }
+
+func Test_Transfer(t *testing.T) {
+	r := NewOn(0, "alpha", 1, 1, travel.East)
+	r.Transfer("beta", -2, 0, travel.North)
+	expected := "beta -2 0 N"
+	if r.GetPlateau() != "beta" || expected != r.String() {
+		t.Fatalf("expected Transfer to have moved robot to %s - moved instead to %v", expected, r)
+	}
+}
+
+func Test_SetType(t *testing.T) {
+	r := New(0, 1, 1, travel.East)
+	if r.GetType() != nil {
+		t.Fatalf("expected a robot to have no type - got %s instead", r.GetType().Name)
+	}
+	scout := &Type{Name: "scout", Step: 2, Commands: []travel.Movement{travel.Left, travel.Move}}
+	r.SetType(scout)
+	if r.GetType() != scout {
+		t.Fatalf("expected GetType to be %s - got %v instead", scout.Name, r.GetType())
+	}
+	if !scout.Can(travel.Move) || scout.Can(travel.Right) {
+		t.Fatal("expected a scout to be able to move and turn left, but not turn right")
+	}
+}
@@ -0,0 +1,30 @@
+package robot
+
+import (
+	"time"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// Type is a kind of rover, such as a scout or a drone, along with what it is capable of.
+// Step is the number of cells each Move carries a robot of the type, Commands is the set of
+// movements it is able to carry out, CrossesObstacles marks a type that can move into a cell
+// holding an obstacle, and Turn is how long it takes to turn; zero means the robot's usual
+// turning time.
+type Type struct {
+	Name             string
+	Step             int
+	Commands         []travel.Movement
+	CrossesObstacles bool
+	Turn             time.Duration
+}
+
+// Can checks if a robot of the type is able to carry out the given movement.
+func (t *Type) Can(move travel.Movement) bool {
+	for _, c := range t.Commands {
+		if c == move {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package travel
+
+const (
+	// NorthEast relates to the hex instruction 'NE'.
+	NorthEast Direction = "NE"
+	// NorthWest relates to the hex instruction 'NW'.
+	NorthWest Direction = "NW"
+	// SouthEast relates to the hex instruction 'SE'.
+	SouthEast Direction = "SE"
+	// SouthWest relates to the hex instruction 'SW'.
+	SouthWest Direction = "SW"
+)
+
+// hexDirections holds the six directions of a hex grid in clockwise order, along with the
+// axial vector of moving one hex in each direction.
+var hexDirections = []struct {
+	direction Direction
+	x, y      int
+}{
+	{East, 1, 0},
+	{SouthEast, 1, -1},
+	{SouthWest, 0, -1},
+	{West, -1, 0},
+	{NorthWest, -1, 1},
+	{NorthEast, 0, 1},
+}
+
+// ParseHexDirection takes a string and aliases it to a Direction instruction upon a hex grid.
+// It returns a ParseDirectionError if the string is not part of the instruction-set consisting
+// of E, SE, SW, W, NW, NE.
+func ParseHexDirection(dir string) (Direction, error) {
+	for _, h := range hexDirections {
+		if dir == string(h.direction) {
+			return h.direction, nil
+		}
+	}
+	return UnknownDirection, &ParseDirectionError{Direction: dir}
+}
+
+// HexTravel is the same as Travel, but upon a hex grid of pointy-topped hexes using axial
+// coordinates, where each row is shifted half a hex to the right of the row beneath it.
+// Turning left (L) or right (R) rotates by 60 degrees, and moving (M) moves to the adjacent hex.
+// I.E if you wish to move (M) when facing north-east (NE) you will move by (0, 1), whereas
+// moving when facing north-west (NW) will move by (-1, 1).
+func HexTravel(direction Direction, move Movement) (int, int, Direction) {
+	for i, h := range hexDirections {
+		if h.direction != direction {
+			continue
+		}
+		switch move {
+		case Left:
+			return 0, 0, hexDirections[(i+len(hexDirections)-1)%len(hexDirections)].direction
+		case Right:
+			return 0, 0, hexDirections[(i+1)%len(hexDirections)].direction
+		case Move:
+			return h.x, h.y, direction
+		}
+		break
+	}
+	return 0, 0, direction
+}
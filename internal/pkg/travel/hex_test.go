@@ -0,0 +1,36 @@
+package travel
+
+import "testing"
+
+func Test_ParseHexDirection(t *testing.T) {
+	for _, d := range []Direction{East, SouthEast, SouthWest, West, NorthWest, NorthEast} {
+		parsed, err := ParseHexDirection(string(d))
+		if err != nil || parsed != d {
+			t.Fatalf("expected %s to produce direction %s - got %s and %v instead", d, d, parsed, err)
+		}
+	}
+	for _, invalid := range []string{"N", "S", "NEE", ""} {
+		if _, err := ParseHexDirection(invalid); err == nil {
+			t.Fatalf("ParseHexDirection should have failed with %s", invalid)
+		}
+	}
+}
+
+func Test_HexTravel(t *testing.T) {
+	var x, y int
+	d := East
+	for i := 0; i < 6; i++ {
+		dx, dy, _ := HexTravel(d, Move)
+		x, y = x+dx, y+dy
+		_, _, d = HexTravel(d, Left)
+	}
+	if x != 0 || y != 0 || d != East {
+		t.Fatalf("expected six moves each followed by a left turn to return to 0 0 E - got %d %d %s instead", x, y, d)
+	}
+	if _, _, d := HexTravel(NorthEast, Right); d != East {
+		t.Fatalf("expected turning right from NE to face E - got %s instead", d)
+	}
+	if dx, dy, d := HexTravel(North, Move); dx != 0 || dy != 0 || d != North {
+		t.Fatalf("expected a square direction to remain stationary upon a hex grid - got %d %d %s instead", dx, dy, d)
+	}
+}
@@ -0,0 +1,150 @@
+package batch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// Mission is a single instruction-set within a batch.
+type Mission struct {
+	Name  string
+	Input string
+}
+
+// Result is the outcome of running a single mission within a batch.
+// Mission is nil whenever the instruction-set could not be parsed, or the mission was never run.
+type Result struct {
+	Name    string
+	Output  string
+	Mission *runner.Mission
+	Err     error
+}
+
+// Options configures how a batch is run.
+type Options struct {
+	// Workers is the maximum number of missions run at once; defaults to the number of CPUs.
+	Workers int
+	// Runner configures how each mission is run.
+	Runner *runner.Options
+}
+
+// ReadDir reads every regular file within a directory as a mission, ordered by file name.
+func ReadDir(dir string) ([]Mission, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var missions []Mission
+	for _, f := range files {
+		if !f.Type().IsRegular() {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		missions = append(missions, Mission{Name: f.Name(), Input: string(b)})
+	}
+	sort.Slice(missions, func(i, j int) bool { return missions[i].Name < missions[j].Name })
+	return missions, nil
+}
+
+// ReadStream reads a stream of missions delimited by one or more blank lines. Since every mission
+// spans several lines, each mission is a block of consecutive lines rather than a single line.
+// Each mission is named after the line number it starts on, for example "line 7". A single line,
+// such as a long string of commands, may be up to 1MiB long.
+func ReadStream(r io.Reader) ([]Mission, error) {
+	var missions []Mission
+	var lines []string
+	var start int
+	flush := func() {
+		if len(lines) > 0 {
+			missions = append(missions, Mission{Name: fmt.Sprintf("line %d", start), Input: strings.Join(lines, "\n")})
+			lines = nil
+		}
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if len(lines) == 0 {
+			start = n
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return missions, scanner.Err()
+}
+
+// Run runs every mission across a bounded pool of workers, returning a result for each
+// mission in the same order as the missions were given.
+// A mission that fails only affects its own result. If the context is cancelled no further
+// missions are started; every mission that had yet to start has the context's error as its
+// result, missions that were running are stopped with a runner.CancelledError, and the
+// context's error is returned once every started mission has stopped.
+func Run(ctx context.Context, missions []Mission, opts *Options) ([]Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	results := make([]Result, len(missions))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = run(ctx, missions[i], opts.Runner)
+			}
+		}()
+	}
+
+	var err error
+	dispatched := 0
+dispatch:
+	for ; dispatched < len(missions); dispatched++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		case jobs <- dispatched:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	for i := dispatched; i < len(missions); i++ {
+		results[i] = Result{Name: missions[i].Name, Err: err}
+	}
+	return results, err
+}
+
+// run runs a single mission.
+func run(ctx context.Context, m Mission, opts *runner.Options) Result {
+	mission, err := runner.SimulateContext(ctx, m.Input, opts)
+	result := Result{Name: m.Name, Mission: mission, Err: err}
+	if mission != nil {
+		result.Output = mission.String()
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+func TestRun_Order(t *testing.T) {
+	var missions []Mission
+	for i := 0; i < 50; i++ {
+		missions = append(missions, Mission{Name: fmt.Sprint(i), Input: fmt.Sprintf("50 50\n%d %d N\nM", i, i)})
+	}
+	results, err := Run(context.Background(), missions, &Options{Workers: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		expected := fmt.Sprintf("%d %d N", i, i+1)
+		if r.Name != fmt.Sprint(i) || r.Output != expected {
+			t.Fatalf("expected result %d to be %s - got %s from mission %s instead", i, expected, r.Output, r.Name)
+		}
+	}
+}
+
+func TestRun_Isolation(t *testing.T) {
+	missions := []Mission{
+		{Name: "valid", Input: "5 5\n1 2 N\nLMLMLMLMM"},
+		{Name: "out-of-bounds", Input: "5 5\n1 2 N\nLMLMLMLMM\n3 3 E\nMMMMM"},
+		{Name: "missing", Input: "5 5"},
+		{Name: "valid", Input: "5 5\n3 3 E\nMMRMMRMRRM"},
+	}
+	results, err := Run(context.Background(), missions, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err != nil || results[0].Output != "1 3 N" || results[3].Err != nil || results[3].Output != "5 1 E" {
+		t.Fatal("expected valid missions to be unaffected by failing missions")
+	}
+	var oe *runner.RobotOutOfBoundsError
+	if !errors.As(results[1].Err, &oe) || results[1].Output != "1 3 N" {
+		t.Fatalf("expected out-of-bounds mission to fail with partial output - got %v instead", results[1].Err)
+	}
+	var me *runner.MissingInputLinesError
+	if !errors.As(results[2].Err, &me) || results[2].Mission != nil {
+		t.Fatalf("expected missing mission to fail with a MissingInputLinesError - got %v instead", results[2].Err)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	missions := []Mission{{Name: "a", Input: "5 5\n1 2 N\nM"}, {Name: "b", Input: "5 5\n1 2 N\nM"}}
+	results, err := Run(ctx, missions, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Run to return context.Canceled - got %v instead", err)
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Fatalf("expected mission %s to have never started - got %v instead", r.Name, r.Err)
+		}
+	}
+}
+
+func TestReadStream(t *testing.T) {
+	stream := `5 5
+1 2 N
+LMLMLMLMM
+
+
+5 5
+3 3 E
+MMRMMRMRRM
+`
+	missions, err := ReadStream(strings.NewReader(stream))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missions) != 2 {
+		t.Fatalf("expected 2 missions to be read - got %d instead", len(missions))
+	}
+	if missions[1].Name != "line 6" || missions[1].Input != "5 5\n3 3 E\nMMRMMRMRRM" {
+		t.Fatalf("expected second mission to start on line 6 - got %s:\n%s", missions[1].Name, missions[1].Input)
+	}
+}
+
+func TestReadStream_LongLine(t *testing.T) {
+	commands := strings.Repeat("LR", 50000)
+	missions, err := ReadStream(strings.NewReader("5 5\n1 2 N\n" + commands + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missions) != 1 || missions[0].Input != "5 5\n1 2 N\n"+commands {
+		t.Fatalf("expected a single mission holding %d commands - got %d missions instead", len(commands), len(missions))
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, input := range map[string]string{"b.txt": "5 5\n3 3 E\nM", "a.txt": "5 5\n1 2 N\nM"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(input), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	missions, err := ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missions) != 2 || missions[0].Name != "a.txt" || missions[1].Name != "b.txt" {
+		t.Fatalf("expected missions to be ordered by file name - got %v instead", missions)
+	}
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// UnsupportedGridError is an error that is returned whenever a mission upon a grid other than
+// a square grid is exported.
+type UnsupportedGridError struct {
+	Grid runner.Grid
+}
+
+// Error outputs a message relating to the grid that cannot be exported.
+func (u *UnsupportedGridError) Error() string {
+	return fmt.Sprintf("missions upon a '%s' grid cannot be exported - only '%s' grids are supported", u.Grid, runner.SquareGrid)
+}
+
+// ImageSizeError is an error that is returned whenever a mission is exported as an image that
+// would hold more pixels than the maximum, such as one of a very large surface. Width and Height
+// are the size in pixels the image would have been.
+type ImageSizeError struct {
+	Width  float64
+	Height float64
+	Max    int
+}
+
+// Error outputs a message relating to the image that is too large.
+func (i *ImageSizeError) Error() string {
+	return fmt.Sprintf("an image of %.0f by %.0f pixels is too large to export - expected at most %d pixels", i.Width, i.Height, i.Max)
+}
@@ -0,0 +1,248 @@
+package export
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+const (
+	// defaultCellSize is the size in pixels of a single cell when no size is given.
+	defaultCellSize = 40
+	// maxPixels is the largest number of pixels a PNG image may hold, which keeps the memory it
+	// needs to around 128MiB.
+	maxPixels = 1 << 25
+	// goldenAngle is used to spread the hues of each robot's colour evenly around the colour wheel.
+	goldenAngle = 137.508
+)
+
+var (
+	// background is the colour drawn behind the surface.
+	background = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	// gridColour is the colour of the lines between each cell.
+	gridColour = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	// voidColour fills any cell within the bounds of a surface that is not part of it.
+	voidColour = color.RGBA{R: 120, G: 120, B: 120, A: 255}
+	// obstacleColour fills any cell holding an obstacle.
+	obstacleColour = color.RGBA{R: 110, G: 70, B: 40, A: 255}
+	// outOfBoundsColour is the colour of the marker drawn where a robot moved out of bounds.
+	outOfBoundsColour = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+)
+
+// Options configures how a mission is exported.
+type Options struct {
+	// CellSize is the size in pixels of a single cell; defaults to 40.
+	CellSize int
+}
+
+// point is a pixel coordinate within an image.
+type point struct{ x, y float64 }
+
+// canvas is a surface that the shapes making up an exported mission can be drawn onto.
+type canvas interface {
+	line(from, to point, c color.RGBA, width float64)
+	circle(centre point, radius float64, c color.RGBA)
+	polygon(points []point, c color.RGBA)
+}
+
+// layout maps the coordinates of a mission's surface onto pixel coordinates.
+// A margin of one cell is kept around the surface so that robots which have moved
+// out of bounds can still be drawn. Whenever the mission spans more than one plateau
+// only the first plateau, named by plateau, is drawn.
+type layout struct {
+	mission  *runner.Mission
+	plateau  string
+	cellSize float64
+	cols     int
+	rows     int
+}
+
+// newLayout creates a layout for the given mission.
+// This function will error if the mission does not take place upon a square grid.
+func newLayout(m *runner.Mission, opts *Options) (*layout, error) {
+	if m.Grid != "" && m.Grid != runner.SquareGrid {
+		return nil, &UnsupportedGridError{Grid: m.Grid}
+	}
+	cellSize := defaultCellSize
+	if opts != nil && opts.CellSize > 0 {
+		cellSize = opts.CellSize
+	}
+	var plateau string
+	if m.Atlas != nil {
+		plateau = m.Atlas.Names()[0]
+	}
+	return &layout{
+		mission:  m,
+		plateau:  plateau,
+		cellSize: float64(cellSize),
+		cols:     m.Surface.Bounds().UpperBoundX - m.Surface.Bounds().LowerBoundX + 3,
+		rows:     m.Surface.Bounds().UpperBoundY - m.Surface.Bounds().LowerBoundY + 3,
+	}, nil
+}
+
+// width returns the width of the image in pixels.
+func (l *layout) width() int {
+	return l.cols * int(l.cellSize)
+}
+
+// height returns the height of the image in pixels.
+func (l *layout) height() int {
+	return l.rows * int(l.cellSize)
+}
+
+// centre returns the pixel at the centre of the given cell, clamping any cell that
+// lies beyond the margin onto it.
+func (l *layout) centre(x, y int) point {
+	s := l.mission.Surface.Bounds()
+	x = clamp(x, s.LowerBoundX-1, s.UpperBoundX+1)
+	y = clamp(y, s.LowerBoundY-1, s.UpperBoundY+1)
+	return point{
+		x: (float64(x-s.LowerBoundX+1) + 0.5) * l.cellSize,
+		y: (float64(s.UpperBoundY-y+1) + 0.5) * l.cellSize,
+	}
+}
+
+// draw draws the grid lines of the surface, fills any cell within its bounds that is not part of
+// it along with any cell holding an obstacle, then draws the path of every robot with its start and end markers, and a marker where any
+// robot moved out of bounds.
+func (l *layout) draw(c canvas) {
+	for i := 1; i < l.cols; i++ {
+		x := float64(i) * l.cellSize
+		c.line(point{x, l.cellSize}, point{x, float64(l.height()) - l.cellSize}, gridColour, 1)
+	}
+	for i := 1; i < l.rows; i++ {
+		y := float64(i) * l.cellSize
+		c.line(point{l.cellSize, y}, point{float64(l.width()) - l.cellSize, y}, gridColour, 1)
+	}
+	b := l.mission.Surface.Bounds()
+	for y := b.LowerBoundY; y <= b.UpperBoundY; y++ {
+		for x := b.LowerBoundX; x <= b.UpperBoundX; x++ {
+			if l.mission.Surface.IsOutOfBounds(x, y) {
+				l.fill(c, x, y, voidColour)
+			} else if l.mission.Obstacles[runner.Obstacle{Plateau: l.plateau, X: x, Y: y}] {
+				l.fill(c, x, y, obstacleColour)
+			}
+		}
+	}
+	for _, t := range l.mission.Tracks {
+		colour := robotColour(t.ID)
+		from := t.Start
+		for _, s := range t.Steps {
+			if from.Plateau == l.plateau && s.Pose.Plateau == l.plateau && (from.X != s.Pose.X || from.Y != s.Pose.Y) {
+				c.line(l.centre(from.X, from.Y), l.centre(s.Pose.X, s.Pose.Y), colour, l.cellSize/10)
+			}
+			from = s.Pose
+		}
+		if t.Start.Plateau == l.plateau {
+			l.drawStart(c, t.Start, colour)
+		}
+		if t.End().Plateau == l.plateau {
+			l.drawEnd(c, t.End(), colour)
+		}
+	}
+	var oe *runner.RobotOutOfBoundsError
+	if errors.As(l.mission.Err, &oe) && oe.Plateau == l.plateau {
+		l.drawOutOfBounds(c, oe.X, oe.Y)
+	}
+}
+
+// fill fills a cell with the given colour.
+func (l *layout) fill(c canvas, x, y int, colour color.RGBA) {
+	centre := l.centre(x, y)
+	half := l.cellSize / 2
+	c.polygon([]point{
+		{centre.x - half, centre.y - half},
+		{centre.x + half, centre.y - half},
+		{centre.x + half, centre.y + half},
+		{centre.x - half, centre.y + half},
+	}, colour)
+}
+
+// drawStart draws a circle with a line pointing in the direction the robot started facing.
+func (l *layout) drawStart(c canvas, p runner.Pose, colour color.RGBA) {
+	centre := l.centre(p.X, p.Y)
+	c.circle(centre, l.cellSize/6, colour)
+	c.line(centre, l.offset(centre, p.Direction, l.cellSize/3), colour, l.cellSize/15)
+}
+
+// drawEnd draws a triangle pointing in the direction the robot finished facing.
+func (l *layout) drawEnd(c canvas, p runner.Pose, colour color.RGBA) {
+	centre := l.centre(p.X, p.Y)
+	size := l.cellSize / 3
+	tip := l.offset(centre, p.Direction, size)
+	dx, dy := (tip.x-centre.x)/size, (tip.y-centre.y)/size
+	if dx == 0 && dy == 0 {
+		c.circle(centre, size, colour)
+		return
+	}
+	back := point{centre.x - dx*size/2, centre.y - dy*size/2}
+	c.polygon([]point{
+		tip,
+		{back.x - dy*size*0.8, back.y + dx*size*0.8},
+		{back.x + dy*size*0.8, back.y - dx*size*0.8},
+	}, colour)
+}
+
+// drawOutOfBounds draws a cross over the cell that a robot moved out of bounds into.
+func (l *layout) drawOutOfBounds(c canvas, x, y int) {
+	centre := l.centre(x, y)
+	size := l.cellSize / 3
+	width := l.cellSize / 12
+	c.line(point{centre.x - size, centre.y - size}, point{centre.x + size, centre.y + size}, outOfBoundsColour, width)
+	c.line(point{centre.x - size, centre.y + size}, point{centre.x + size, centre.y - size}, outOfBoundsColour, width)
+}
+
+// offset returns the pixel the given distance away from p in the given direction.
+func (l *layout) offset(p point, d travel.Direction, distance float64) point {
+	x, y, _ := travel.Travel(d, travel.Move)
+	return point{p.x + float64(x)*distance, p.y - float64(y)*distance}
+}
+
+// robotColour returns a distinct colour for the given robot ID.
+func robotColour(id int) color.RGBA {
+	hue := math.Mod(float64(id)*goldenAngle, 360)
+	return hslToRGB(hue, 0.7, 0.45)
+}
+
+// hslToRGB converts a hue in degrees along with a saturation and lightness between 0 and 1
+// into an RGB colour.
+func hslToRGB(h, s, l float64) color.RGBA {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return color.RGBA{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+		A: 255,
+	}
+}
+
+// clamp restricts v to be between lower and upper.
+func clamp(v, lower, upper int) int {
+	if v < lower {
+		return lower
+	}
+	if v > upper {
+		return upper
+	}
+	return v
+}
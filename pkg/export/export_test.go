@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+func testMission(t *testing.T, input string) *runner.Mission {
+	m, _ := runner.Simulate(input, nil)
+	if m == nil {
+		t.Fatal("Simulate() should have returned a mission")
+	}
+	return m
+}
+
+func TestSVG(t *testing.T) {
+	m := testMission(t, `
+2 2
+0 0 N
+MRM
+2 2 S
+MMM`)
+	var b bytes.Buffer
+	if err := SVG(&b, m, nil); err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="200" height="200"`) {
+		t.Fatalf("expected SVG to be 200x200 pixels - got %s instead", strings.SplitN(svg, "\n", 2)[0])
+	}
+	if strings.Count(svg, "<polygon") != 2 {
+		t.Fatalf("expected SVG to contain an end marker for each robot:\n%s", svg)
+	}
+	for _, id := range []int{0, 2} {
+		if !strings.Contains(svg, hex(robotColour(id))) {
+			t.Fatalf("expected SVG to draw robot ID %d in %s", id, hex(robotColour(id)))
+		}
+	}
+	if !strings.Contains(svg, hex(outOfBoundsColour)) {
+		t.Fatal("expected SVG to contain an out-of-bounds marker")
+	}
+}
+
+func TestPNG(t *testing.T) {
+	m := testMission(t, `
+2 2
+0 0 N
+MM`)
+	var b bytes.Buffer
+	if err := PNG(&b, m, &Options{CellSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 50 || img.Bounds().Dy() != 50 {
+		t.Fatalf("expected PNG to be 50x50 pixels - got %v instead", img.Bounds())
+	}
+	// the path between 0,0 and 0,1 passes through the pixel 15,30.
+	r, g, b2, _ := img.At(15, 30).RGBA()
+	c := robotColour(0)
+	if uint8(r>>8) != c.R || uint8(g>>8) != c.G || uint8(b2>>8) != c.B {
+		t.Fatalf("expected the path of robot ID 0 to be drawn in %s", hex(c))
+	}
+	r, g, b2, _ = img.At(45, 5).RGBA()
+	if uint8(r>>8) != background.R || uint8(g>>8) != background.G || uint8(b2>>8) != background.B {
+		t.Fatal("expected the margin to be drawn in the background colour")
+	}
+}
+
+func TestPNG_TooLarge(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		cellSize int
+	}{
+		"surface":   {"10000 10000\n0 0 N\nM", 0},
+		"cell size": {"5 5\n0 0 N\nM", 1000},
+		"overflow":  {"9223372036854775806 9223372036854775806\n0 0 N\nM", 0},
+	}
+	for name, test := range tests {
+		var b bytes.Buffer
+		err := PNG(&b, testMission(t, test.input), &Options{CellSize: test.cellSize})
+		if _, ok := err.(*ImageSizeError); !ok {
+			t.Fatalf("%s: expected an ImageSizeError - got %v instead", name, err)
+		}
+	}
+}
+
+func Test_robotColour(t *testing.T) {
+	seen := make(map[string]int)
+	for id := 0; id < 20; id += 2 {
+		c := hex(robotColour(id))
+		if other, ok := seen[c]; ok {
+			t.Fatalf("robot ID %d and %d should have distinct colours - both were %s", id, other, c)
+		}
+		seen[c] = id
+	}
+}
+
+func TestSVG_Hex(t *testing.T) {
+	m, _ := runner.Simulate("2\n0 0 E\nM", &runner.Options{Grid: runner.HexGrid})
+	if m == nil {
+		t.Fatal("Simulate() should have returned a mission")
+	}
+	var b bytes.Buffer
+	err := SVG(&b, m, nil)
+	if _, ok := err.(*UnsupportedGridError); !ok {
+		t.Fatalf("expected an UnsupportedGridError - got %v instead", err)
+	}
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// pngCanvas draws shapes directly onto an image.
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+// line draws a line by stamping circles along its length.
+func (p *pngCanvas) line(from, to point, c color.RGBA, width float64) {
+	length := math.Hypot(to.x-from.x, to.y-from.y)
+	steps := int(math.Ceil(length * 2))
+	if steps == 0 {
+		steps = 1
+	}
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		p.circle(point{from.x + (to.x-from.x)*t, from.y + (to.y-from.y)*t}, width/2, c)
+	}
+}
+
+// circle fills every pixel whose centre lies within the given radius.
+func (p *pngCanvas) circle(centre point, radius float64, c color.RGBA) {
+	if radius < 0.5 {
+		radius = 0.5
+	}
+	minX, maxX := int(math.Floor(centre.x-radius)), int(math.Ceil(centre.x+radius))
+	minY, maxY := int(math.Floor(centre.y-radius)), int(math.Ceil(centre.y+radius))
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			if math.Hypot(float64(x)+0.5-centre.x, float64(y)+0.5-centre.y) <= radius {
+				p.img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+// polygon fills every pixel whose centre lies within the given polygon.
+func (p *pngCanvas) polygon(points []point, c color.RGBA) {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, pt := range points {
+		minX, maxX = math.Min(minX, pt.x), math.Max(maxX, pt.x)
+		minY, maxY = math.Min(minY, pt.y), math.Max(maxY, pt.y)
+	}
+	for y := int(math.Floor(minY)); y <= int(math.Ceil(maxY)); y++ {
+		for x := int(math.Floor(minX)); x <= int(math.Ceil(maxX)); x++ {
+			if contains(points, point{float64(x) + 0.5, float64(y) + 0.5}) {
+				p.img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+// contains reports whether pt lies within the polygon using the even-odd rule.
+func contains(points []point, pt point) bool {
+	inside := false
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a, b := points[i], points[j]
+		if (a.y > pt.y) != (b.y > pt.y) && pt.x < (b.x-a.x)*(pt.y-a.y)/(b.y-a.y)+a.x {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// PNG writes a PNG image of a mission to w.
+// The image contains the same elements as those written by SVG.
+// This function will error if the image would hold more than 2^25 pixels.
+func PNG(w io.Writer, m *runner.Mission, opts *Options) error {
+	l, err := newLayout(m, opts)
+	if err != nil {
+		return err
+	}
+	// the size is worked out in floating point as it may overflow an int upon a huge surface.
+	b := m.Surface.Bounds()
+	width := (float64(b.UpperBoundX) - float64(b.LowerBoundX) + 3) * l.cellSize
+	height := (float64(b.UpperBoundY) - float64(b.LowerBoundY) + 3) * l.cellSize
+	if width*height > maxPixels {
+		return &ImageSizeError{Width: width, Height: height, Max: maxPixels}
+	}
+	p := &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, l.width(), l.height()))}
+	draw.Draw(p.img, p.img.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+	l.draw(p)
+	return png.Encode(w, p.img)
+}
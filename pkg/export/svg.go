@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// svgCanvas draws shapes as SVG elements.
+type svgCanvas struct {
+	buf bytes.Buffer
+}
+
+// line draws an SVG line element.
+func (s *svgCanvas) line(from, to point, c color.RGBA, width float64) {
+	fmt.Fprintf(&s.buf, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%.1f" stroke-linecap="round"/>`+"\n", from.x, from.y, to.x, to.y, hex(c), width)
+}
+
+// circle draws an SVG circle element.
+func (s *svgCanvas) circle(centre point, radius float64, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`+"\n", centre.x, centre.y, radius, hex(c))
+}
+
+// polygon draws an SVG polygon element.
+func (s *svgCanvas) polygon(points []point, c color.RGBA) {
+	s.buf.WriteString(`<polygon points="`)
+	for i, p := range points {
+		if i > 0 {
+			s.buf.WriteString(" ")
+		}
+		fmt.Fprintf(&s.buf, "%.1f,%.1f", p.x, p.y)
+	}
+	fmt.Fprintf(&s.buf, `" fill="%s"/>`+"\n", hex(c))
+}
+
+// SVG writes an SVG image of a mission to w.
+// The image contains the grid lines of the surface, the path of each robot in a colour
+// distinct to its ID, a circle marking where each robot started along with its heading,
+// a triangle pointing in the direction each robot finished facing, and a cross marking
+// where any robot moved out of bounds. Only missions upon a square grid can be exported.
+func SVG(w io.Writer, m *runner.Mission, opts *Options) error {
+	l, err := newLayout(m, opts)
+	if err != nil {
+		return err
+	}
+	s := &svgCanvas{}
+	fmt.Fprintf(&s.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", l.width(), l.height(), l.width(), l.height())
+	fmt.Fprintf(&s.buf, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(background))
+	l.draw(s)
+	s.buf.WriteString("</svg>\n")
+	_, err = s.buf.WriteTo(w)
+	return err
+}
+
+// hex returns the hexadecimal representation of a colour, for example #ff0000.
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
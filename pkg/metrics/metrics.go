@@ -0,0 +1,171 @@
+// Package metrics records counters and histograms describing the missions that have been run,
+// and exposes them in the Prometheus text format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the buckets the duration of each
+// mission is counted within.
+var DefaultBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+
+// Registry records metrics for every mission run through it. A nil Registry records nothing.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	missions uint64
+	robots   uint64
+	commands uint64
+	failures map[string]uint64
+	// buckets are the upper bounds of the duration histogram, and counts the number of
+	// missions whose duration fell within each bucket, with a final bucket for the rest.
+	buckets []float64
+	counts  []uint64
+	sum     float64
+}
+
+// New creates a registry whose duration histogram uses DefaultBuckets.
+func New() *Registry {
+	return &Registry{
+		failures: make(map[string]uint64),
+		buckets:  DefaultBuckets,
+		counts:   make([]uint64, len(DefaultBuckets)+1),
+	}
+}
+
+// Simulate runs an instruction-set via runner.SimulateContext with the registry as the
+// runner.Recorder of the options, replacing any recorder already given.
+func (r *Registry) Simulate(ctx context.Context, input string, opts *runner.Options) (*runner.Mission, error) {
+	run := runner.Options{}
+	if opts != nil {
+		run = *opts
+	}
+	run.Recorder = r
+	return runner.SimulateContext(ctx, input, &run)
+}
+
+// Record records a mission that has been run, the error it returned, if any, and how long
+// it took to run. The mission may be nil if it failed before the surface was constructed.
+func (r *Registry) Record(mission *runner.Mission, err error, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.missions++
+	if mission != nil {
+		r.robots += uint64(len(mission.Tracks))
+		r.commands += uint64(mission.Steps())
+	}
+	if err != nil {
+		r.failures[ErrorType(err)]++
+	}
+	seconds := elapsed.Seconds()
+	r.sum += seconds
+	i := sort.SearchFloat64s(r.buckets, seconds)
+	r.counts[i]++
+}
+
+// RecordRobot records a robot placed upon a surface outside of any mission, such as by a stream
+// of commands.
+func (r *Registry) RecordRobot() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.robots++
+}
+
+// RecordCommand records a command carried out by a robot outside of any mission, such as by a
+// stream of commands.
+func (r *Registry) RecordCommand() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands++
+}
+
+// ErrorType returns the name of the type of a given error, for example "RobotOutOfBoundsError".
+func ErrorType(err error) string {
+	t := reflect.TypeOf(err)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return "unknown"
+	}
+	return t.Name()
+}
+
+// Write writes every metric to a given writer in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ew := &errWriter{w: w}
+	writeCounter(ew, "mars_rover_missions_total", "Missions run.", r.missions)
+	writeCounter(ew, "mars_rover_robots_total", "Robots placed upon a surface.", r.robots)
+	writeCounter(ew, "mars_rover_commands_total", "Commands carried out by every robot.", r.commands)
+
+	ew.printf("# HELP mars_rover_mission_failures_total Missions halted by an error, by the type of error.\n")
+	ew.printf("# TYPE mars_rover_mission_failures_total counter\n")
+	types := make([]string, 0, len(r.failures))
+	for t := range r.failures {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		ew.printf("mars_rover_mission_failures_total{error=%q} %d\n", t, r.failures[t])
+	}
+
+	ew.printf("# HELP mars_rover_mission_duration_seconds How long each mission took to run.\n")
+	ew.printf("# TYPE mars_rover_mission_duration_seconds histogram\n")
+	var cumulative uint64
+	for i, upper := range r.buckets {
+		cumulative += r.counts[i]
+		ew.printf("mars_rover_mission_duration_seconds_bucket{le=%q} %d\n", strconv.FormatFloat(upper, 'g', -1, 64), cumulative)
+	}
+	cumulative += r.counts[len(r.buckets)]
+	ew.printf("mars_rover_mission_duration_seconds_bucket{le=\"+Inf\"} %d\n", cumulative)
+	ew.printf("mars_rover_mission_duration_seconds_sum %s\n", strconv.FormatFloat(r.sum, 'g', -1, 64))
+	ew.printf("mars_rover_mission_duration_seconds_count %d\n", cumulative)
+	return ew.err
+}
+
+// ServeHTTP serves every metric in the Prometheus text format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.Write(w)
+}
+
+// writeCounter writes a single counter along with its help text.
+func writeCounter(ew *errWriter, name, help string, value uint64) {
+	ew.printf("# HELP %s %s\n# TYPE %s counter\n%s %d\n", name, help, name, name, value)
+}
+
+// errWriter writes to a writer until the first error, which is kept.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+// printf formats and writes to the writer unless a previous write has failed.
+func (e *errWriter) printf(format string, a ...interface{}) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = fmt.Fprintf(e.w, format, a...)
+}
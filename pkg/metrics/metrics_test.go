@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+func testMetrics(t *testing.T, r *Registry) string {
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func testContains(t *testing.T, output string, lines ...string) {
+	for _, line := range lines {
+		if !strings.Contains(output, line+"\n") {
+			t.Fatalf("expected the metrics to contain '%s' - got %s instead", line, output)
+		}
+	}
+}
+
+func TestRegistry_Simulate(t *testing.T) {
+	r := New()
+	ctx := context.Background()
+	r.Simulate(ctx, "5 5\n1 2 N\nLMLMLMLMM\n3 3 E\nMMRMMRMRRM", nil)
+	r.Simulate(ctx, "5 5\n0 0 S\nM", nil)
+	r.Simulate(ctx, "5 5\n0 0 S\nX", nil)
+	r.Simulate(ctx, "5", nil)
+	testContains(t, testMetrics(t, r),
+		"mars_rover_missions_total 4",
+		"mars_rover_robots_total 4",
+		"mars_rover_commands_total 20",
+		`mars_rover_mission_failures_total{error="MissingInputLinesError"} 1`,
+		`mars_rover_mission_failures_total{error="ParseRobotMovementError"} 1`,
+		`mars_rover_mission_failures_total{error="RobotOutOfBoundsError"} 1`,
+		`mars_rover_mission_duration_seconds_bucket{le="+Inf"} 4`,
+		"mars_rover_mission_duration_seconds_count 4",
+	)
+}
+
+func TestRegistry_Recorder(t *testing.T) {
+	r := New()
+	runner.Simulate("5 5\n1 2 N\nLM", &runner.Options{Recorder: r})
+	r.RecordRobot()
+	r.RecordCommand()
+	testContains(t, testMetrics(t, r),
+		"mars_rover_missions_total 1",
+		"mars_rover_robots_total 2",
+		"mars_rover_commands_total 3",
+	)
+}
+
+func TestRegistry_Histogram(t *testing.T) {
+	r := New()
+	r.Record(nil, nil, 50*time.Microsecond)
+	r.Record(nil, nil, 2*time.Millisecond)
+	r.Record(nil, nil, time.Minute)
+	testContains(t, testMetrics(t, r),
+		`mars_rover_mission_duration_seconds_bucket{le="0.0001"} 1`,
+		`mars_rover_mission_duration_seconds_bucket{le="0.001"} 1`,
+		`mars_rover_mission_duration_seconds_bucket{le="0.005"} 2`,
+		`mars_rover_mission_duration_seconds_bucket{le="5"} 2`,
+		`mars_rover_mission_duration_seconds_bucket{le="+Inf"} 3`,
+		"mars_rover_mission_duration_seconds_sum 60.00205",
+	)
+}
+
+func TestRegistry_Nil(t *testing.T) {
+	var r *Registry
+	if _, err := r.Simulate(context.Background(), "5 5\n1 2 N\nM", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	if ErrorType(&runner.CancelledError{Err: context.Canceled}) != "CancelledError" {
+		t.Fatal("expected the name of the error's type")
+	}
+	if ErrorType(errors.New("failed")) != "errorString" {
+		t.Fatal("expected the name of an unexported error's type")
+	}
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := New()
+	r.Record(nil, nil, time.Millisecond)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatalf("expected the Prometheus text format - got %s instead", rec.Header().Get("Content-Type"))
+	}
+	testContains(t, rec.Body.String(), "# TYPE mars_rover_missions_total counter", "mars_rover_missions_total 1")
+}
@@ -0,0 +1,209 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+const (
+	// emptyCell is drawn for any cell that has not been visited.
+	emptyCell = "."
+	// trailCell is drawn for any cell that a robot has passed through.
+	trailCell = "*"
+	// obstacleCell is drawn for any cell holding an obstacle.
+	obstacleCell = "#"
+	// voidCell is drawn for any cell within the bounds of a surface that is not part of it.
+	voidCell = " "
+	// unknownGlyph is drawn for a robot whose direction has no glyph.
+	unknownGlyph = "?"
+	// hexField is the narrowest width a hex is drawn within, which fits a robot's direction.
+	hexField = 2
+)
+
+// glyphs maps the direction a robot is facing to the glyph used to draw it.
+var glyphs = map[travel.Direction]string{
+	travel.North: "^",
+	travel.East:  ">",
+	travel.South: "v",
+	travel.West:  "<",
+}
+
+// Options configures how a surface is drawn.
+type Options struct {
+	// Trails draws every cell a robot has passed through.
+	Trails bool
+}
+
+// cell is a coordinate within a surface.
+type cell struct{ x, y int }
+
+// Mission draws the surface of a mission along with the robots that have moved across it.
+// Whenever the mission spans more than one plateau, each plateau is drawn in turn beneath its
+// name, along with the robots and trails upon it. A mission upon a hex grid is drawn as Hex, and
+// any obstacle within the mission is drawn as '#'.
+// Use Mission.At to draw the state of a mission after any given step.
+func Mission(m *runner.Mission, opts *Options) string {
+	draw := grid
+	if m.Grid == runner.HexGrid {
+		draw = hex
+	}
+	if m.Atlas == nil {
+		return draw(m.Surface, "", m.Tracks, m.Obstacles, opts)
+	}
+	var grids []string
+	for _, name := range m.Atlas.Names() {
+		grids = append(grids, name+"\n"+draw(m.Atlas.Surface(name), name, m.Tracks, m.Obstacles, opts))
+	}
+	return strings.Join(grids, "\n\n")
+}
+
+// Grid draws a surface as a text grid with the y-axis increasing upward, labelling each
+// row and column with its coordinate. Only the rectangle bounding the surface is drawn, and
+// any cell within it that is not part of the surface is left blank.
+// Each robot is drawn at its final position with a glyph showing its heading; ^ > v <
+// for N E S W respectively. Robots that have left the bounding rectangle are not drawn.
+func Grid(surface plateau.Surface, tracks []*runner.Track, opts *Options) string {
+	return grid(surface, "", tracks, nil, opts)
+}
+
+// grid draws a surface in the same way as Grid, but only draws the poses and obstacles upon the
+// named plateau.
+func grid(surface plateau.Surface, name string, tracks []*runner.Track, obstacles map[runner.Obstacle]bool, opts *Options) string {
+	cells := plot(name, tracks, obstacles, opts, squareGlyph)
+
+	bounds := surface.Bounds()
+	xWidth := maxWidth(bounds.LowerBoundX, bounds.UpperBoundX)
+	yWidth := maxWidth(bounds.LowerBoundY, bounds.UpperBoundY)
+	var b strings.Builder
+	for y := bounds.UpperBoundY; y >= bounds.LowerBoundY; y-- {
+		b.WriteString(pad(strconv.Itoa(y), yWidth))
+		for x := bounds.LowerBoundX; x <= bounds.UpperBoundX; x++ {
+			b.WriteString(" ")
+			b.WriteString(pad(cellAt(surface, cells, x, y), xWidth))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat(" ", yWidth))
+	for x := bounds.LowerBoundX; x <= bounds.UpperBoundX; x++ {
+		b.WriteString(" ")
+		b.WriteString(pad(strconv.Itoa(x), xWidth))
+	}
+	return b.String()
+}
+
+// Hex draws a surface upon a hex grid as a text grid of pointy-topped hexes in the same way as
+// Grid, but with each row shifted half a hex to the right of the row beneath it, so that moving
+// north-east keeps the same x-coordinate. Each column is labelled beneath the lowest row, and its
+// x-coordinate holds along the diagonal running up and to the right from its label.
+// Each robot is drawn at its final position with its heading; E SE SW W NW NE.
+func Hex(surface plateau.Surface, tracks []*runner.Track, opts *Options) string {
+	return hex(surface, "", tracks, nil, opts)
+}
+
+// hex draws a surface in the same way as Hex, but only draws the poses and obstacles upon the
+// named plateau.
+func hex(surface plateau.Surface, name string, tracks []*runner.Track, obstacles map[runner.Obstacle]bool, opts *Options) string {
+	cells := plot(name, tracks, obstacles, opts, hexGlyph)
+
+	bounds := surface.Bounds()
+	field := max(hexField, maxWidth(bounds.LowerBoundX, bounds.UpperBoundX))
+	// each hex is drawn within a slot of even width, so that a row can be shifted by half of it.
+	slot := field + 2 - field%2
+	yWidth := maxWidth(bounds.LowerBoundY, bounds.UpperBoundY)
+	var b strings.Builder
+	for y := bounds.UpperBoundY; y >= bounds.LowerBoundY; y-- {
+		b.WriteString(pad(strconv.Itoa(y), yWidth))
+		b.WriteString(strings.Repeat(" ", (y-bounds.LowerBoundY)*slot/2))
+		for x := bounds.LowerBoundX; x <= bounds.UpperBoundX; x++ {
+			b.WriteString(pad(cellAt(surface, cells, x, y), slot))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat(" ", yWidth))
+	for x := bounds.LowerBoundX; x <= bounds.UpperBoundX; x++ {
+		b.WriteString(pad(strconv.Itoa(x), slot))
+	}
+	return b.String()
+}
+
+// plot returns the glyph drawn within each cell upon the named plateau that holds a trail, obstacle
+// or robot, where an obstacle is drawn over a trail and a robot is drawn over both.
+func plot(name string, tracks []*runner.Track, obstacles map[runner.Obstacle]bool, opts *Options, glyph func(travel.Direction) string) map[cell]string {
+	if opts == nil {
+		opts = &Options{}
+	}
+	cells := make(map[cell]string)
+	if opts.Trails {
+		for _, t := range tracks {
+			if t.Start.Plateau == name {
+				cells[cell{t.Start.X, t.Start.Y}] = trailCell
+			}
+			for _, s := range t.Steps {
+				if s.Pose.Plateau == name {
+					cells[cell{s.Pose.X, s.Pose.Y}] = trailCell
+				}
+			}
+		}
+	}
+	for o := range obstacles {
+		if o.Plateau == name {
+			cells[cell{o.X, o.Y}] = obstacleCell
+		}
+	}
+	for _, t := range tracks {
+		end := t.End()
+		if end.Plateau != name {
+			continue
+		}
+		cells[cell{end.X, end.Y}] = glyph(end.Direction)
+	}
+	return cells
+}
+
+// cellAt returns the glyph drawn within the given cell of a surface.
+func cellAt(surface plateau.Surface, cells map[cell]string, x, y int) string {
+	if c, ok := cells[cell{x, y}]; ok {
+		return c
+	}
+	if surface.IsOutOfBounds(x, y) {
+		return voidCell
+	}
+	return emptyCell
+}
+
+// squareGlyph returns the glyph drawn for a robot facing the given direction upon a square grid.
+func squareGlyph(d travel.Direction) string {
+	if glyph, ok := glyphs[d]; ok {
+		return glyph
+	}
+	return unknownGlyph
+}
+
+// hexGlyph returns the glyph drawn for a robot facing the given direction upon a hex grid.
+func hexGlyph(d travel.Direction) string {
+	if _, err := travel.ParseHexDirection(string(d)); err != nil {
+		return unknownGlyph
+	}
+	return string(d)
+}
+
+// maxWidth returns the widest label needed for any coordinate between lower and upper.
+func maxWidth(lower, upper int) int {
+	l, u := len(strconv.Itoa(lower)), len(strconv.Itoa(upper))
+	if l > u {
+		return l
+	}
+	return u
+}
+
+// pad right-aligns s within the given width.
+func pad(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return strings.Repeat(" ", width-len(s)) + s
+}
@@ -0,0 +1,160 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+func testRender(t *testing.T, input string, step int, opts *Options, expected string) {
+	m, err := runner.Simulate(input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := Mission(m.At(step), opts)
+	if expected != actual {
+		t.Fatalf("expected render to output:\n%s\ninstead got:\n%s", expected, actual)
+	}
+}
+
+func TestMission_Headings(t *testing.T) {
+	input := `
+2 2
+0 0 N
+M
+2 0 E
+L
+2 2 E
+R
+0 2 N
+L`
+	expected := `2 < . v
+1 ^ . .
+0 . . ^
+  0 1 2`
+	testRender(t, input, -1, nil, expected)
+}
+
+func TestMission_LowerBounds(t *testing.T) {
+	input := `
+-2 -1 1 1
+-2 -1 N
+MMRM`
+	expected := ` 1  *  >  .  .
+ 0  *  .  .  .
+-1  *  .  .  .
+   -2 -1  0  1`
+	testRender(t, input, -1, &Options{Trails: true}, expected)
+}
+
+func TestMission_Polygon(t *testing.T) {
+	input := `
+0 0 4 0 4 2 2 2 2 4 0 4
+0 0 N
+MMMMRMM`
+	expected := `4 * * >    
+3 * . .    
+2 * . . . .
+1 * . . . .
+0 * . . . .
+  0 1 2 3 4`
+	testRender(t, input, -1, &Options{Trails: true}, expected)
+}
+
+func TestMission_Plateaus(t *testing.T) {
+	input := `
+plateau alpha 2 1
+plateau beta 1 1
+portal alpha 2 0 beta 0 1 E
+0 0 E
+MMM`
+	expected := `alpha
+1 . . .
+0 * * .
+  0 1 2
+
+beta
+1 * >
+0 . .
+  0 1`
+	testRender(t, input, -1, &Options{Trails: true}, expected)
+}
+
+func TestMission_Trails(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLMLMLMM`
+	expected := `5 . . . . . .
+4 . . . . . .
+3 . ^ . . . .
+2 * * . . . .
+1 * * . . . .
+0 . . . . . .
+  0 1 2 3 4 5`
+	testRender(t, input, -1, &Options{Trails: true}, expected)
+}
+
+func TestMission_Step(t *testing.T) {
+	input := `
+3 3
+1 2 N
+LMLMLMLMM
+3 3 S
+MMRMMRMRRM`
+	expected := `3 . . . .
+2 < . . .
+1 . . . .
+0 . . . .
+  0 1 2 3`
+	testRender(t, input, 2, nil, expected)
+}
+
+func TestGrid_WideLabels(t *testing.T) {
+	m, err := runner.Simulate("10 1\n10 0 W\nM", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `1  .  .  .  .  .  .  .  .  .  .  .
+0  .  .  .  .  .  .  .  .  .  <  .
+   0  1  2  3  4  5  6  7  8  9 10`
+	actual := Grid(m.Surface, m.Tracks, nil)
+	if expected != actual {
+		t.Fatalf("expected render to output:\n%s\ninstead got:\n%s", expected, actual)
+	}
+}
+
+func TestMission_Hex(t *testing.T) {
+	input := `
+1
+-1 0 E
+MLM
+1 -1 NW
+R`
+	m, err := runner.Simulate(input, &runner.Options{Grid: runner.HexGrid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ` 1       .  NE    
+ 0     *   *   .
+-1       .  NE
+    -1   0   1`
+	if actual := Mission(m, &Options{Trails: true}); expected != actual {
+		t.Fatalf("expected render to output:\n%s\ninstead got:\n%s", expected, actual)
+	}
+}
+
+func TestMission_Obstacles(t *testing.T) {
+	input := `
+3 2
+type drone 1 LRM cross 1s
+obstacle 1 1
+obstacle 2 0
+0 1 E drone
+MM`
+	expected := `2 . . . .
+1 * # > .
+0 . . # .
+  0 1 2 3`
+	testRender(t, input, -1, &Options{Trails: true}, expected)
+}
@@ -0,0 +1,820 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: marsrover.proto
+
+package marsroverpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Pose is where a robot is positioned within a surface and the direction it is facing.
+type Pose struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	X     int64                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y     int64                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	// direction is one of N, E, S or W.
+	Direction     string `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pose) Reset() {
+	*x = Pose{}
+	mi := &file_marsrover_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pose) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pose) ProtoMessage() {}
+
+func (x *Pose) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pose.ProtoReflect.Descriptor instead.
+func (*Pose) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Pose) GetX() int64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Pose) GetY() int64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Pose) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+// Surface holds the upper-right boundary of a surface, along with its lower-left boundary which
+// defaults to 0,0.
+type Surface struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpperX        int64                  `protobuf:"varint,1,opt,name=upper_x,json=upperX,proto3" json:"upper_x,omitempty"`
+	UpperY        int64                  `protobuf:"varint,2,opt,name=upper_y,json=upperY,proto3" json:"upper_y,omitempty"`
+	LowerX        int64                  `protobuf:"varint,3,opt,name=lower_x,json=lowerX,proto3" json:"lower_x,omitempty"`
+	LowerY        int64                  `protobuf:"varint,4,opt,name=lower_y,json=lowerY,proto3" json:"lower_y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Surface) Reset() {
+	*x = Surface{}
+	mi := &file_marsrover_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Surface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Surface) ProtoMessage() {}
+
+func (x *Surface) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Surface.ProtoReflect.Descriptor instead.
+func (*Surface) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Surface) GetUpperX() int64 {
+	if x != nil {
+		return x.UpperX
+	}
+	return 0
+}
+
+func (x *Surface) GetUpperY() int64 {
+	if x != nil {
+		return x.UpperY
+	}
+	return 0
+}
+
+func (x *Surface) GetLowerX() int64 {
+	if x != nil {
+		return x.LowerX
+	}
+	return 0
+}
+
+func (x *Surface) GetLowerY() int64 {
+	if x != nil {
+		return x.LowerY
+	}
+	return 0
+}
+
+// Robot holds where a robot is placed and the commands it carries out.
+type Robot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pose  *Pose                  `protobuf:"bytes,1,opt,name=pose,proto3" json:"pose,omitempty"`
+	// commands is a string of L, R and M commands.
+	Commands      string `protobuf:"bytes,2,opt,name=commands,proto3" json:"commands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Robot) Reset() {
+	*x = Robot{}
+	mi := &file_marsrover_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Robot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Robot) ProtoMessage() {}
+
+func (x *Robot) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Robot.ProtoReflect.Descriptor instead.
+func (*Robot) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Robot) GetPose() *Pose {
+	if x != nil {
+		return x.Pose
+	}
+	return nil
+}
+
+func (x *Robot) GetCommands() string {
+	if x != nil {
+		return x.Commands
+	}
+	return ""
+}
+
+// RunMissionRequest holds either an instruction-set in the same format as runner.Run, or a
+// surface and the robots placed upon it.
+type RunMissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instructions  string                 `protobuf:"bytes,1,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	Surface       *Surface               `protobuf:"bytes,2,opt,name=surface,proto3" json:"surface,omitempty"`
+	Robots        []*Robot               `protobuf:"bytes,3,rep,name=robots,proto3" json:"robots,omitempty"`
+	Simultaneous  bool                   `protobuf:"varint,4,opt,name=simultaneous,proto3" json:"simultaneous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMissionRequest) Reset() {
+	*x = RunMissionRequest{}
+	mi := &file_marsrover_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMissionRequest) ProtoMessage() {}
+
+func (x *RunMissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMissionRequest.ProtoReflect.Descriptor instead.
+func (*RunMissionRequest) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RunMissionRequest) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *RunMissionRequest) GetSurface() *Surface {
+	if x != nil {
+		return x.Surface
+	}
+	return nil
+}
+
+func (x *RunMissionRequest) GetRobots() []*Robot {
+	if x != nil {
+		return x.Robots
+	}
+	return nil
+}
+
+func (x *RunMissionRequest) GetSimultaneous() bool {
+	if x != nil {
+		return x.Simultaneous
+	}
+	return false
+}
+
+// RobotResult holds where a robot finished and whether it carried out all of its commands.
+type RobotResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pose  *Pose                  `protobuf:"bytes,2,opt,name=pose,proto3" json:"pose,omitempty"`
+	Steps int64                  `protobuf:"varint,3,opt,name=steps,proto3" json:"steps,omitempty"`
+	Done  bool                   `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	// plateau is the name of the plateau the robot finished upon whenever the mission spans several.
+	Plateau string `protobuf:"bytes,5,opt,name=plateau,proto3" json:"plateau,omitempty"`
+	// type is the name of the robot's type of rover, if it has one.
+	Type          string `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RobotResult) Reset() {
+	*x = RobotResult{}
+	mi := &file_marsrover_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RobotResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RobotResult) ProtoMessage() {}
+
+func (x *RobotResult) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RobotResult.ProtoReflect.Descriptor instead.
+func (*RobotResult) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RobotResult) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RobotResult) GetPose() *Pose {
+	if x != nil {
+		return x.Pose
+	}
+	return nil
+}
+
+func (x *RobotResult) GetSteps() int64 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+func (x *RobotResult) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *RobotResult) GetPlateau() string {
+	if x != nil {
+		return x.Plateau
+	}
+	return ""
+}
+
+func (x *RobotResult) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// RunMissionResponse holds the outcome of a mission, along with an error if the mission failed.
+type RunMissionResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Robots []*RobotResult         `protobuf:"bytes,1,rep,name=robots,proto3" json:"robots,omitempty"`
+	// output holds the final resting positions in the same format as runner.Run.
+	Output        string `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	Error         *Error `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMissionResponse) Reset() {
+	*x = RunMissionResponse{}
+	mi := &file_marsrover_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMissionResponse) ProtoMessage() {}
+
+func (x *RunMissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMissionResponse.ProtoReflect.Descriptor instead.
+func (*RunMissionResponse) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunMissionResponse) GetRobots() []*RobotResult {
+	if x != nil {
+		return x.Robots
+	}
+	return nil
+}
+
+func (x *RunMissionResponse) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *RunMissionResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Error is the machine-readable representation of an error; the codes are the same as those
+// returned by the HTTP API.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RobotId       *int64                 `protobuf:"varint,3,opt,name=robot_id,json=robotId,proto3,oneof" json:"robot_id,omitempty"`
+	Limit         string                 `protobuf:"bytes,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_marsrover_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetRobotId() int64 {
+	if x != nil && x.RobotId != nil {
+		return *x.RobotId
+	}
+	return 0
+}
+
+func (x *Error) GetLimit() string {
+	if x != nil {
+		return x.Limit
+	}
+	return ""
+}
+
+// CommandRequest either places a robot upon a surface, replacing any robot already placed,
+// or carries out a single command.
+type CommandRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*CommandRequest_Place
+	//	*CommandRequest_Command
+	Request       isCommandRequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandRequest) Reset() {
+	*x = CommandRequest{}
+	mi := &file_marsrover_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandRequest) ProtoMessage() {}
+
+func (x *CommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandRequest.ProtoReflect.Descriptor instead.
+func (*CommandRequest) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CommandRequest) GetRequest() isCommandRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *CommandRequest) GetPlace() *Place {
+	if x != nil {
+		if x, ok := x.Request.(*CommandRequest_Place); ok {
+			return x.Place
+		}
+	}
+	return nil
+}
+
+func (x *CommandRequest) GetCommand() string {
+	if x != nil {
+		if x, ok := x.Request.(*CommandRequest_Command); ok {
+			return x.Command
+		}
+	}
+	return ""
+}
+
+type isCommandRequest_Request interface {
+	isCommandRequest_Request()
+}
+
+type CommandRequest_Place struct {
+	Place *Place `protobuf:"bytes,1,opt,name=place,proto3,oneof"`
+}
+
+type CommandRequest_Command struct {
+	// command is one of L, R or M.
+	Command string `protobuf:"bytes,2,opt,name=command,proto3,oneof"`
+}
+
+func (*CommandRequest_Place) isCommandRequest_Request() {}
+
+func (*CommandRequest_Command) isCommandRequest_Request() {}
+
+// Place places a robot upon a surface.
+type Place struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Surface       *Surface               `protobuf:"bytes,1,opt,name=surface,proto3" json:"surface,omitempty"`
+	Pose          *Pose                  `protobuf:"bytes,2,opt,name=pose,proto3" json:"pose,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Place) Reset() {
+	*x = Place{}
+	mi := &file_marsrover_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Place) ProtoMessage() {}
+
+func (x *Place) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Place.ProtoReflect.Descriptor instead.
+func (*Place) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Place) GetSurface() *Surface {
+	if x != nil {
+		return x.Surface
+	}
+	return nil
+}
+
+func (x *Place) GetPose() *Pose {
+	if x != nil {
+		return x.Pose
+	}
+	return nil
+}
+
+// CommandResponse holds the pose of the robot after a request, along with an error if the
+// request failed.
+type CommandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pose          *Pose                  `protobuf:"bytes,1,opt,name=pose,proto3" json:"pose,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandResponse) Reset() {
+	*x = CommandResponse{}
+	mi := &file_marsrover_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResponse) ProtoMessage() {}
+
+func (x *CommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_marsrover_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResponse.ProtoReflect.Descriptor instead.
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return file_marsrover_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CommandResponse) GetPose() *Pose {
+	if x != nil {
+		return x.Pose
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+var File_marsrover_proto protoreflect.FileDescriptor
+
+const file_marsrover_proto_rawDesc = "" +
+	"\n" +
+	"\x0fmarsrover.proto\x12\fmarsrover.v1\"@\n" +
+	"\x04Pose\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x03R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x03R\x01y\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\"m\n" +
+	"\aSurface\x12\x17\n" +
+	"\aupper_x\x18\x01 \x01(\x03R\x06upperX\x12\x17\n" +
+	"\aupper_y\x18\x02 \x01(\x03R\x06upperY\x12\x17\n" +
+	"\alower_x\x18\x03 \x01(\x03R\x06lowerX\x12\x17\n" +
+	"\alower_y\x18\x04 \x01(\x03R\x06lowerY\"K\n" +
+	"\x05Robot\x12&\n" +
+	"\x04pose\x18\x01 \x01(\v2\x12.marsrover.v1.PoseR\x04pose\x12\x1a\n" +
+	"\bcommands\x18\x02 \x01(\tR\bcommands\"\xb9\x01\n" +
+	"\x11RunMissionRequest\x12\"\n" +
+	"\finstructions\x18\x01 \x01(\tR\finstructions\x12/\n" +
+	"\asurface\x18\x02 \x01(\v2\x15.marsrover.v1.SurfaceR\asurface\x12+\n" +
+	"\x06robots\x18\x03 \x03(\v2\x13.marsrover.v1.RobotR\x06robots\x12\"\n" +
+	"\fsimultaneous\x18\x04 \x01(\bR\fsimultaneous\"\x9d\x01\n" +
+	"\vRobotResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12&\n" +
+	"\x04pose\x18\x02 \x01(\v2\x12.marsrover.v1.PoseR\x04pose\x12\x14\n" +
+	"\x05steps\x18\x03 \x01(\x03R\x05steps\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\x12\x18\n" +
+	"\aplateau\x18\x05 \x01(\tR\aplateau\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\"\x8a\x01\n" +
+	"\x12RunMissionResponse\x121\n" +
+	"\x06robots\x18\x01 \x03(\v2\x19.marsrover.v1.RobotResultR\x06robots\x12\x16\n" +
+	"\x06output\x18\x02 \x01(\tR\x06output\x12)\n" +
+	"\x05error\x18\x03 \x01(\v2\x13.marsrover.v1.ErrorR\x05error\"x\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
+	"\brobot_id\x18\x03 \x01(\x03H\x00R\arobotId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\tR\x05limitB\v\n" +
+	"\t_robot_id\"d\n" +
+	"\x0eCommandRequest\x12+\n" +
+	"\x05place\x18\x01 \x01(\v2\x13.marsrover.v1.PlaceH\x00R\x05place\x12\x1a\n" +
+	"\acommand\x18\x02 \x01(\tH\x00R\acommandB\t\n" +
+	"\arequest\"`\n" +
+	"\x05Place\x12/\n" +
+	"\asurface\x18\x01 \x01(\v2\x15.marsrover.v1.SurfaceR\asurface\x12&\n" +
+	"\x04pose\x18\x02 \x01(\v2\x12.marsrover.v1.PoseR\x04pose\"d\n" +
+	"\x0fCommandResponse\x12&\n" +
+	"\x04pose\x18\x01 \x01(\v2\x12.marsrover.v1.PoseR\x04pose\x12)\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.marsrover.v1.ErrorR\x05error2\xaf\x01\n" +
+	"\tMarsRover\x12O\n" +
+	"\n" +
+	"RunMission\x12\x1f.marsrover.v1.RunMissionRequest\x1a .marsrover.v1.RunMissionResponse\x12Q\n" +
+	"\x0eStreamCommands\x12\x1c.marsrover.v1.CommandRequest\x1a\x1d.marsrover.v1.CommandResponse(\x010\x01B6Z4github.com/juubisnake/mars-rover/pkg/rpc/marsroverpbb\x06proto3"
+
+var (
+	file_marsrover_proto_rawDescOnce sync.Once
+	file_marsrover_proto_rawDescData []byte
+)
+
+func file_marsrover_proto_rawDescGZIP() []byte {
+	file_marsrover_proto_rawDescOnce.Do(func() {
+		file_marsrover_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_marsrover_proto_rawDesc), len(file_marsrover_proto_rawDesc)))
+	})
+	return file_marsrover_proto_rawDescData
+}
+
+var file_marsrover_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_marsrover_proto_goTypes = []any{
+	(*Pose)(nil),               // 0: marsrover.v1.Pose
+	(*Surface)(nil),            // 1: marsrover.v1.Surface
+	(*Robot)(nil),              // 2: marsrover.v1.Robot
+	(*RunMissionRequest)(nil),  // 3: marsrover.v1.RunMissionRequest
+	(*RobotResult)(nil),        // 4: marsrover.v1.RobotResult
+	(*RunMissionResponse)(nil), // 5: marsrover.v1.RunMissionResponse
+	(*Error)(nil),              // 6: marsrover.v1.Error
+	(*CommandRequest)(nil),     // 7: marsrover.v1.CommandRequest
+	(*Place)(nil),              // 8: marsrover.v1.Place
+	(*CommandResponse)(nil),    // 9: marsrover.v1.CommandResponse
+}
+var file_marsrover_proto_depIdxs = []int32{
+	0,  // 0: marsrover.v1.Robot.pose:type_name -> marsrover.v1.Pose
+	1,  // 1: marsrover.v1.RunMissionRequest.surface:type_name -> marsrover.v1.Surface
+	2,  // 2: marsrover.v1.RunMissionRequest.robots:type_name -> marsrover.v1.Robot
+	0,  // 3: marsrover.v1.RobotResult.pose:type_name -> marsrover.v1.Pose
+	4,  // 4: marsrover.v1.RunMissionResponse.robots:type_name -> marsrover.v1.RobotResult
+	6,  // 5: marsrover.v1.RunMissionResponse.error:type_name -> marsrover.v1.Error
+	8,  // 6: marsrover.v1.CommandRequest.place:type_name -> marsrover.v1.Place
+	1,  // 7: marsrover.v1.Place.surface:type_name -> marsrover.v1.Surface
+	0,  // 8: marsrover.v1.Place.pose:type_name -> marsrover.v1.Pose
+	0,  // 9: marsrover.v1.CommandResponse.pose:type_name -> marsrover.v1.Pose
+	6,  // 10: marsrover.v1.CommandResponse.error:type_name -> marsrover.v1.Error
+	3,  // 11: marsrover.v1.MarsRover.RunMission:input_type -> marsrover.v1.RunMissionRequest
+	7,  // 12: marsrover.v1.MarsRover.StreamCommands:input_type -> marsrover.v1.CommandRequest
+	5,  // 13: marsrover.v1.MarsRover.RunMission:output_type -> marsrover.v1.RunMissionResponse
+	9,  // 14: marsrover.v1.MarsRover.StreamCommands:output_type -> marsrover.v1.CommandResponse
+	13, // [13:15] is the sub-list for method output_type
+	11, // [11:13] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_marsrover_proto_init() }
+func file_marsrover_proto_init() {
+	if File_marsrover_proto != nil {
+		return
+	}
+	file_marsrover_proto_msgTypes[6].OneofWrappers = []any{}
+	file_marsrover_proto_msgTypes[7].OneofWrappers = []any{
+		(*CommandRequest_Place)(nil),
+		(*CommandRequest_Command)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_marsrover_proto_rawDesc), len(file_marsrover_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_marsrover_proto_goTypes,
+		DependencyIndexes: file_marsrover_proto_depIdxs,
+		MessageInfos:      file_marsrover_proto_msgTypes,
+	}.Build()
+	File_marsrover_proto = out.File
+	file_marsrover_proto_goTypes = nil
+	file_marsrover_proto_depIdxs = nil
+}
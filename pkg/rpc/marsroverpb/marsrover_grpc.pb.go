@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: marsrover.proto
+
+package marsroverpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MarsRover_RunMission_FullMethodName     = "/marsrover.v1.MarsRover/RunMission"
+	MarsRover_StreamCommands_FullMethodName = "/marsrover.v1.MarsRover/StreamCommands"
+)
+
+// MarsRoverClient is the client API for MarsRover service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MarsRover runs missions and controls robots across a surface.
+type MarsRoverClient interface {
+	// RunMission runs a mission in full and returns the final pose of each robot.
+	RunMission(ctx context.Context, in *RunMissionRequest, opts ...grpc.CallOption) (*RunMissionResponse, error)
+	// StreamCommands places a robot upon a surface and carries out commands one at a time,
+	// responding with the robot's pose after each command.
+	StreamCommands(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CommandRequest, CommandResponse], error)
+}
+
+type marsRoverClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMarsRoverClient(cc grpc.ClientConnInterface) MarsRoverClient {
+	return &marsRoverClient{cc}
+}
+
+func (c *marsRoverClient) RunMission(ctx context.Context, in *RunMissionRequest, opts ...grpc.CallOption) (*RunMissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunMissionResponse)
+	err := c.cc.Invoke(ctx, MarsRover_RunMission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marsRoverClient) StreamCommands(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CommandRequest, CommandResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MarsRover_ServiceDesc.Streams[0], MarsRover_StreamCommands_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CommandRequest, CommandResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MarsRover_StreamCommandsClient = grpc.BidiStreamingClient[CommandRequest, CommandResponse]
+
+// MarsRoverServer is the server API for MarsRover service.
+// All implementations must embed UnimplementedMarsRoverServer
+// for forward compatibility.
+//
+// MarsRover runs missions and controls robots across a surface.
+type MarsRoverServer interface {
+	// RunMission runs a mission in full and returns the final pose of each robot.
+	RunMission(context.Context, *RunMissionRequest) (*RunMissionResponse, error)
+	// StreamCommands places a robot upon a surface and carries out commands one at a time,
+	// responding with the robot's pose after each command.
+	StreamCommands(grpc.BidiStreamingServer[CommandRequest, CommandResponse]) error
+	mustEmbedUnimplementedMarsRoverServer()
+}
+
+// UnimplementedMarsRoverServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMarsRoverServer struct{}
+
+func (UnimplementedMarsRoverServer) RunMission(context.Context, *RunMissionRequest) (*RunMissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunMission not implemented")
+}
+func (UnimplementedMarsRoverServer) StreamCommands(grpc.BidiStreamingServer[CommandRequest, CommandResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCommands not implemented")
+}
+func (UnimplementedMarsRoverServer) mustEmbedUnimplementedMarsRoverServer() {}
+func (UnimplementedMarsRoverServer) testEmbeddedByValue()                   {}
+
+// UnsafeMarsRoverServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MarsRoverServer will
+// result in compilation errors.
+type UnsafeMarsRoverServer interface {
+	mustEmbedUnimplementedMarsRoverServer()
+}
+
+func RegisterMarsRoverServer(s grpc.ServiceRegistrar, srv MarsRoverServer) {
+	// If the following call pancis, it indicates UnimplementedMarsRoverServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MarsRover_ServiceDesc, srv)
+}
+
+func _MarsRover_RunMission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunMissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarsRoverServer).RunMission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarsRover_RunMission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarsRoverServer).RunMission(ctx, req.(*RunMissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarsRover_StreamCommands_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MarsRoverServer).StreamCommands(&grpc.GenericServerStream[CommandRequest, CommandResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MarsRover_StreamCommandsServer = grpc.BidiStreamingServer[CommandRequest, CommandResponse]
+
+// MarsRover_ServiceDesc is the grpc.ServiceDesc for MarsRover service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MarsRover_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "marsrover.v1.MarsRover",
+	HandlerType: (*MarsRoverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RunMission",
+			Handler:    _MarsRover_RunMission_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCommands",
+			Handler:       _MarsRover_StreamCommands_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "marsrover.proto",
+}
@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+	"github.com/juubisnake/mars-rover/internal/pkg/robot"
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+	"github.com/juubisnake/mars-rover/pkg/metrics"
+	"github.com/juubisnake/mars-rover/pkg/rpc/marsroverpb"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+	"github.com/juubisnake/mars-rover/pkg/server"
+)
+
+// CodeRobotNotPlaced relates to a command sent before a robot has been placed upon a surface.
+const CodeRobotNotPlaced = "robot_not_placed"
+
+// Options configures the service.
+type Options struct {
+	// Limits restricts the resources each mission may use; nil means there are no limits.
+	Limits *runner.Limits
+	// Timeout cancels any mission that runs for longer; zero means there is no timeout.
+	Timeout time.Duration
+	// Metrics records every mission that is run, along with every robot placed and command carried
+	// out by a stream of commands; nil means nothing is recorded.
+	Metrics *metrics.Registry
+	// Logger logs every mission that is run; nil means nothing is logged.
+	Logger *slog.Logger
+}
+
+// service implements marsroverpb.MarsRoverServer.
+type service struct {
+	marsroverpb.UnimplementedMarsRoverServer
+	opts *Options
+}
+
+// New creates a MarsRoverServer that can be registered with a grpc.Server via
+// marsroverpb.RegisterMarsRoverServer.
+func New(opts *Options) marsroverpb.MarsRoverServer {
+	if opts == nil {
+		opts = &Options{}
+	}
+	return &service{opts: opts}
+}
+
+// RunMission runs a mission in full. Errors returned by the runner are held within the
+// response alongside the partial results of the mission.
+func (s *service) RunMission(ctx context.Context, req *marsroverpb.RunMissionRequest) (*marsroverpb.RunMissionResponse, error) {
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+	mission, err := s.opts.Metrics.Simulate(ctx, instructions(req), &runner.Options{
+		Simultaneous: req.GetSimultaneous(),
+		Limits:       s.opts.Limits,
+		Logger:       s.opts.Logger,
+	})
+	resp := &marsroverpb.RunMissionResponse{}
+	if mission != nil {
+		resp.Output = mission.String()
+		for _, t := range mission.Tracks {
+			resp.Robots = append(resp.Robots, &marsroverpb.RobotResult{
+				Id:      int64(t.ID),
+				Pose:    pose(t.End().X, t.End().Y, t.End().Direction),
+				Steps:   int64(len(t.Steps)),
+				Done:    t.Done,
+				Plateau: t.End().Plateau,
+				Type:    t.Type,
+			})
+		}
+	}
+	if err != nil {
+		resp.Error = describe(err)
+	}
+	return resp, nil
+}
+
+// instructions converts the request into an instruction-set.
+func instructions(req *marsroverpb.RunMissionRequest) string {
+	if req.GetSurface() == nil {
+		return req.GetInstructions()
+	}
+	surface := req.GetSurface()
+	lines := []string{fmt.Sprintf("%d %d %d %d", surface.GetLowerX(), surface.GetLowerY(), surface.GetUpperX(), surface.GetUpperY())}
+	for _, r := range req.GetRobots() {
+		p := r.GetPose()
+		lines = append(lines, fmt.Sprintf("%d %d %s", p.GetX(), p.GetY(), p.GetDirection()), r.GetCommands())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StreamCommands places a robot upon a surface and carries out each command it receives,
+// responding with the robot's pose after each request.
+// A robot that moves out of bounds is lost, and must be placed again before it can carry
+// out any further commands.
+func (s *service) StreamCommands(stream marsroverpb.MarsRover_StreamCommandsServer) error {
+	var surface plateau.Surface
+	var r *robot.Robot
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp := &marsroverpb.CommandResponse{}
+		switch request := req.GetRequest().(type) {
+		case *marsroverpb.CommandRequest_Place:
+			surface, r, err = place(request.Place)
+			if err != nil {
+				resp.Error = describe(err)
+				break
+			}
+			s.opts.Metrics.RecordRobot()
+		case *marsroverpb.CommandRequest_Command:
+			if r == nil {
+				resp.Error = &marsroverpb.Error{Code: CodeRobotNotPlaced, Message: "a robot must be placed before it can carry out commands"}
+				break
+			}
+			move, err := travel.ParseMovement(request.Command)
+			if err != nil {
+				resp.Error = describe(&runner.ParseRobotMovementError{Movement: request.Command, ID: r.GetID(), Err: err})
+				break
+			}
+			r.Move(travel.Travel(r.GetDirection(), move))
+			s.opts.Metrics.RecordCommand()
+			if surface.IsOutOfBounds(r.GetX(), r.GetY()) {
+				resp.Pose = pose(r.GetX(), r.GetY(), r.GetDirection())
+				resp.Error = describe(&runner.RobotOutOfBoundsError{ID: r.GetID(), X: r.GetX(), Y: r.GetY()})
+				r = nil
+			}
+		default:
+			resp.Error = &marsroverpb.Error{Code: server.CodeInvalidRequest, Message: "a request must either place a robot or carry out a command"}
+		}
+		if r != nil && resp.Pose == nil {
+			resp.Pose = pose(r.GetX(), r.GetY(), r.GetDirection())
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// place constructs the surface and robot within a place request.
+func place(p *marsroverpb.Place) (plateau.Surface, *robot.Robot, error) {
+	s := p.GetSurface()
+	surface, err := plateau.NewWithLowerBounds(int(s.GetLowerX()), int(s.GetLowerY()), int(s.GetUpperX()), int(s.GetUpperY()))
+	if err != nil {
+		return nil, nil, &runner.SurfaceError{Err: err}
+	}
+	direction, err := travel.ParseDirection(p.GetPose().GetDirection())
+	if err != nil {
+		return nil, nil, &runner.ParseRobotDirectionError{Direction: p.GetPose().GetDirection(), Err: err}
+	}
+	x, y := int(p.GetPose().GetX()), int(p.GetPose().GetY())
+	if surface.IsOutOfBounds(x, y) {
+		return nil, nil, &runner.RobotOutOfBoundsError{X: x, Y: y}
+	}
+	return surface, robot.New(0, x, y, direction), nil
+}
+
+// pose converts a position and direction into its protobuf representation.
+func pose(x, y int, d travel.Direction) *marsroverpb.Pose {
+	return &marsroverpb.Pose{X: int64(x), Y: int64(y), Direction: string(d)}
+}
+
+// describe converts an error returned by the runner into its protobuf representation.
+func describe(err error) *marsroverpb.Error {
+	e := server.Describe(err)
+	pe := &marsroverpb.Error{Code: e.Code, Message: e.Message, Limit: string(e.Limit)}
+	if e.RobotID != nil {
+		id := int64(*e.RobotID)
+		pe.RobotId = &id
+	}
+	return pe
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/juubisnake/mars-rover/pkg/metrics"
+	"github.com/juubisnake/mars-rover/pkg/rpc/marsroverpb"
+	"github.com/juubisnake/mars-rover/pkg/server"
+)
+
+func testClient(t *testing.T, opts *Options) marsroverpb.MarsRoverClient {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	marsroverpb.RegisterMarsRoverServer(s, New(opts))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return marsroverpb.NewMarsRoverClient(conn)
+}
+
+func TestRunMission(t *testing.T) {
+	client := testClient(t, nil)
+	resp, err := client.RunMission(context.Background(), &marsroverpb.RunMissionRequest{
+		Surface: &marsroverpb.Surface{UpperX: 5, UpperY: 5},
+		Robots: []*marsroverpb.Robot{
+			{Pose: &marsroverpb.Pose{X: 1, Y: 2, Direction: "N"}, Commands: "LMLMLMLMM"},
+			{Pose: &marsroverpb.Pose{X: 3, Y: 3, Direction: "E"}, Commands: "MMRMMRMRRMMM"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetOutput() != "1 3 N" || len(resp.GetRobots()) != 2 {
+		t.Fatalf("expected the partial results of the mission - got %v instead", resp)
+	}
+	if resp.GetError().GetCode() != server.CodeRobotOutOfBounds || resp.GetError().GetRobotId() != 2 {
+		t.Fatalf("expected robot ID 2 to move out of bounds - got %v instead", resp.GetError())
+	}
+	resp, err = client.RunMission(context.Background(), &marsroverpb.RunMissionRequest{Instructions: "5 5\n1 2 N\nLMLMLMLMM"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetOutput() != "1 3 N" || resp.GetError() != nil {
+		t.Fatalf("expected the final resting positions - got %v instead", resp)
+	}
+	resp, err = client.RunMission(context.Background(), &marsroverpb.RunMissionRequest{Instructions: "5 5\ntype scout 2 LRM avoid 1s\n1 1 N scout\nM"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if robots := resp.GetRobots(); len(robots) != 1 || robots[0].GetType() != "scout" {
+		t.Fatalf("expected a robot of type scout - got %v instead", robots)
+	}
+}
+
+func TestRunMission_Plateaus(t *testing.T) {
+	resp, err := testClient(t, nil).RunMission(context.Background(), &marsroverpb.RunMissionRequest{Instructions: "plateau alpha 5 5\nplateau beta 3 3\nbeta 1 1 N\nM\nalpha 0 0 E\nM"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	robots := resp.GetRobots()
+	if len(robots) != 2 || robots[0].GetPlateau() != "beta" || robots[1].GetPlateau() != "alpha" {
+		t.Fatalf("expected robots upon beta and alpha - got %v instead", robots)
+	}
+}
+
+func TestStreamCommands(t *testing.T) {
+	registry := metrics.New()
+	stream, err := testClient(t, &Options{Metrics: registry}).StreamCommands(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func(req *marsroverpb.CommandRequest) *marsroverpb.CommandResponse {
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	command := func(c string) *marsroverpb.CommandResponse {
+		return send(&marsroverpb.CommandRequest{Request: &marsroverpb.CommandRequest_Command{Command: c}})
+	}
+
+	if resp := command("M"); resp.GetError().GetCode() != CodeRobotNotPlaced {
+		t.Fatalf("expected commands before placing a robot to fail - got %v instead", resp.GetError())
+	}
+	resp := send(&marsroverpb.CommandRequest{Request: &marsroverpb.CommandRequest_Place{Place: &marsroverpb.Place{
+		Surface: &marsroverpb.Surface{UpperX: 1, UpperY: 1},
+		Pose:    &marsroverpb.Pose{X: 0, Y: 0, Direction: "N"},
+	}}})
+	if resp.GetError() != nil || resp.GetPose().GetDirection() != "N" {
+		t.Fatalf("expected the robot to be placed at 0 0 N - got %v instead", resp)
+	}
+	if resp := command("M"); resp.GetPose().GetY() != 1 {
+		t.Fatalf("expected the robot to move to 0 1 N - got %v instead", resp.GetPose())
+	}
+	if resp := command("R"); resp.GetPose().GetDirection() != "E" {
+		t.Fatalf("expected the robot to turn to 0 1 E - got %v instead", resp.GetPose())
+	}
+	if resp := command("X"); resp.GetError().GetCode() != server.CodeParseRobotMovement || resp.GetPose().GetDirection() != "E" {
+		t.Fatalf("expected an invalid command to leave the robot at 0 1 E - got %v instead", resp)
+	}
+	command("M")
+	resp = command("M")
+	if resp.GetError().GetCode() != server.CodeRobotOutOfBounds || resp.GetPose().GetX() != 2 {
+		t.Fatalf("expected the robot to move out of bounds at 2 1 - got %v instead", resp)
+	}
+	if resp := command("L"); resp.GetError().GetCode() != CodeRobotNotPlaced {
+		t.Fatalf("expected the robot to be lost after moving out of bounds - got %v instead", resp.GetError())
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	registry.Write(&buf)
+	for _, line := range []string{"mars_rover_robots_total 1\n", "mars_rover_commands_total 4\n"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Fatalf("expected the metrics to contain %q - got %s instead", line, buf.String())
+		}
+	}
+}
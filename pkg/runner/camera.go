@@ -0,0 +1,232 @@
+package runner
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+const (
+	// sightSamples is the number of points sampled along each cell's width of a line of sight
+	// when checking whether an obstacle blocks it.
+	sightSamples = 8
+	// sightNudge is the tiny amount each line of sight is offset to either side of itself, and
+	// the tolerance given to the edges of a camera's cone.
+	sightNudge = 1e-6
+)
+
+// Camera is the field of view of a robot's on board camera. Range is the furthest distance in
+// cells the camera can see, and Angle is the width in degrees of the cone it can see, centred upon
+// the direction the robot faces; an angle of 360 sees all the way around the robot.
+// Upon a square grid distance is measured between the centres of cells, and upon a hex grid it is
+// the number of hexes between them.
+type Camera struct {
+	Range int
+	Angle float64
+}
+
+// check returns a CameraError if the camera is unable to see anything.
+func (c Camera) check(id *int) error {
+	if c.Range < 0 || c.Angle <= 0 || c.Angle > 360 {
+		return &CameraError{ID: id, Range: c.Range, Angle: c.Angle}
+	}
+	return nil
+}
+
+// Cell is a single cell upon a surface. Plateau is the name of the plateau it lies upon whenever
+// a mission spans more than one.
+type Cell struct {
+	Plateau string
+	X       int
+	Y       int
+}
+
+// String outputs the cell's coordinate, preceded by its plateau if it has one, I.E "beta 1 2".
+func (c Cell) String() string {
+	if c.Plateau != "" {
+		return fmt.Sprintf("%s %d %d", c.Plateau, c.X, c.Y)
+	}
+	return fmt.Sprintf("%d %d", c.X, c.Y)
+}
+
+// Visibility is what the robots of a mission were able to see with their cameras. Seen holds
+// every cell seen by any robot, Cells is the number of cells across every surface of the mission,
+// and Unseen holds every cell that no robot saw, ordered by plateau and then by coordinate.
+type Visibility struct {
+	Robots []*RobotVisibility
+	Seen   map[Cell]bool
+	Cells  int
+	Unseen []Cell
+}
+
+// RobotVisibility is what a single robot was able to see with its camera. Steps holds the cells
+// the robot could see once it had been placed, followed by the cells it could see after each of
+// its steps, each ordered by plateau and then by coordinate, and Seen holds every cell it saw.
+// A robot that has moved out of bounds sees nothing.
+type RobotVisibility struct {
+	ID    int
+	Steps [][]Cell
+	Seen  map[Cell]bool
+}
+
+// Coverage returns the proportion of the cells across every surface that were seen by any robot.
+func (v *Visibility) Coverage() float64 {
+	if v.Cells == 0 {
+		return 0
+	}
+	return float64(len(v.Seen)) / float64(v.Cells)
+}
+
+// Complete checks if every cell across every surface was seen by at least one robot.
+func (v *Visibility) Complete() bool {
+	return len(v.Unseen) == 0
+}
+
+// String outputs the number of cells seen by each robot, followed by the number of cells seen by
+// every robot combined, I.E
+//
+//	robot 0: 14 cells seen
+//	robot 2: 9 cells seen
+//	combined: 20/36 cells seen (55.6%)
+func (v *Visibility) String() string {
+	var lines []string
+	for _, r := range v.Robots {
+		lines = append(lines, fmt.Sprintf("robot %d: %d cells seen", r.ID, len(r.Seen)))
+	}
+	lines = append(lines, fmt.Sprintf("combined: %d/%d cells seen (%.1f%%)", len(v.Seen), v.Cells, 100*v.Coverage()))
+	return strings.Join(lines, "\n")
+}
+
+// Visibility works out which cells each robot was able to see with its camera once it had been
+// placed and after each of its steps, where cameras overrides the camera of the robot with the
+// given ID. A robot sees every cell within its camera's range and cone that lies upon the same
+// plateau as it, unless an obstacle lies between the robot and the cell; the obstacle itself is
+// seen. A robot always sees the cell it occupies.
+//
+// Visibility returns a CameraError if any camera is unable to see anything.
+func (m *Mission) Visibility(camera Camera, cameras map[int]Camera) (*Visibility, error) {
+	if err := camera.check(nil); err != nil {
+		return nil, err
+	}
+	for id, c := range cameras {
+		if err := c.check(&id); err != nil {
+			return nil, err
+		}
+	}
+	v := &Visibility{Seen: make(map[Cell]bool)}
+	for _, t := range m.Tracks {
+		c := camera
+		if override, ok := cameras[t.ID]; ok {
+			c = override
+		}
+		r := &RobotVisibility{ID: t.ID, Seen: make(map[Cell]bool)}
+		poses := []Pose{t.Start}
+		for _, step := range t.Steps {
+			poses = append(poses, step.Pose)
+		}
+		for _, pose := range poses {
+			visible := m.visible(c, pose)
+			for _, cell := range visible {
+				r.Seen[cell] = true
+				v.Seen[cell] = true
+			}
+			r.Steps = append(r.Steps, visible)
+		}
+		v.Robots = append(v.Robots, r)
+	}
+	for _, name := range m.plateaus() {
+		surface := m.surfaceOf(name)
+		b := surface.Bounds()
+		for x := b.LowerBoundX; x <= b.UpperBoundX; x++ {
+			for y := b.LowerBoundY; y <= b.UpperBoundY; y++ {
+				if surface.IsOutOfBounds(x, y) {
+					continue
+				}
+				v.Cells++
+				if c := (Cell{Plateau: name, X: x, Y: y}); !v.Seen[c] {
+					v.Unseen = append(v.Unseen, c)
+				}
+			}
+		}
+	}
+	return v, nil
+}
+
+// plateaus returns the name of every plateau within the mission, where the surface of a mission
+// that does not span several plateaus is named by the empty string.
+func (m *Mission) plateaus() []string {
+	if m.Atlas == nil {
+		return []string{""}
+	}
+	return m.Atlas.Names()
+}
+
+// visible returns every cell a camera can see from the given pose, ordered by plateau and then by
+// coordinate.
+func (m *Mission) visible(camera Camera, pose Pose) []Cell {
+	surface := m.surfaceOf(pose.Plateau)
+	if surface == nil || surface.IsOutOfBounds(pose.X, pose.Y) {
+		return nil
+	}
+	vx, vy, _ := m.Grid.travel(pose.Direction, travel.Move)
+	hx, hy := m.Grid.centre(vx, vy)
+	heading := math.Atan2(hy, hx)
+	var cells []Cell
+	for x := pose.X - camera.Range; x <= pose.X+camera.Range; x++ {
+		for y := pose.Y - camera.Range; y <= pose.Y+camera.Range; y++ {
+			if surface.IsOutOfBounds(x, y) {
+				continue
+			}
+			dx, dy := x-pose.X, y-pose.Y
+			if dx != 0 || dy != 0 {
+				if m.Grid.distance(dx, dy) > float64(camera.Range) {
+					continue
+				}
+				if camera.Angle < 360 {
+					cx, cy := m.Grid.centre(dx, dy)
+					turn := math.Abs(math.Remainder(math.Atan2(cy, cx)-heading, 2*math.Pi))
+					if turn*180/math.Pi > camera.Angle/2+sightNudge {
+						continue
+					}
+				}
+				if m.blocked(pose.Plateau, pose.X, pose.Y, x, y) {
+					continue
+				}
+			}
+			cells = append(cells, Cell{Plateau: pose.Plateau, X: x, Y: y})
+		}
+	}
+	return cells
+}
+
+// blocked checks if an obstacle lies between two cells upon the named plateau, excluding the
+// cells themselves. A line of sight that passes exactly between two cells, such as through the
+// corner shared by two square cells, is only blocked if it is blocked upon both sides.
+func (m *Mission) blocked(name string, fromX, fromY, toX, toY int) bool {
+	if len(m.Obstacles) == 0 {
+		return false
+	}
+	ax, ay := m.Grid.centre(fromX, fromY)
+	bx, by := m.Grid.centre(toX, toY)
+	length := math.Hypot(bx-ax, by-ay)
+	// offset the line to either side of itself by a tiny amount.
+	nx, ny := (ay-by)/length*sightNudge, (bx-ax)/length*sightNudge
+	samples := int(math.Ceil(length * sightSamples))
+	for _, side := range []float64{1, -1} {
+		var obstructed bool
+		for i := 1; i < samples && !obstructed; i++ {
+			t := float64(i) / float64(samples)
+			x, y := m.Grid.cellAt(ax+(bx-ax)*t+nx*side, ay+(by-ay)*t+ny*side)
+			if (x == fromX && y == fromY) || (x == toX && y == toY) {
+				continue
+			}
+			obstructed = m.Obstacles[Obstacle{Plateau: name, X: x, Y: y}]
+		}
+		if !obstructed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testVisibility(t *testing.T, input string, opts *Options, camera Camera, cameras map[int]Camera) *Visibility {
+	mission, err := Simulate(input, opts)
+	if mission == nil {
+		t.Fatal(err)
+	}
+	visibility, err := mission.Visibility(camera, cameras)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return visibility
+}
+
+func TestMission_Visibility(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected []Cell
+	}{
+		"cone":     {"5 5\n2 2 N\nL", []Cell{{X: 1, Y: 3}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 4}, {X: 3, Y: 3}}},
+		"obstacle": {"5 5\nobstacle 2 3\n2 2 N\nL", []Cell{{X: 1, Y: 3}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 3, Y: 3}}},
+		"edge":     {"5 5\n0 5 N\nL", []Cell{{X: 0, Y: 5}}},
+	}
+	for name, test := range tests {
+		visibility := testVisibility(t, test.input, &Options{}, Camera{Range: 2, Angle: 90}, nil)
+		steps := visibility.Robots[0].Steps
+		if len(steps) != 2 {
+			t.Fatalf("%s: expected the cells seen once placed and after 1 step - got %d instead", name, len(steps))
+		}
+		if !reflect.DeepEqual(steps[0], test.expected) {
+			t.Fatalf("%s: expected %v to be seen - got %v instead", name, test.expected, steps[0])
+		}
+	}
+}
+
+func TestMission_Visibility_Combined(t *testing.T) {
+	input := `
+3 3
+0 0 N
+MMM
+3 3 S
+MMM`
+	visibility := testVisibility(t, input, &Options{}, Camera{Range: 1, Angle: 90}, nil)
+	if visibility.Cells != 16 || visibility.Complete() {
+		t.Fatalf("expected 16 cells without a complete view - got %d cells instead", visibility.Cells)
+	}
+	if len(visibility.Robots[0].Seen)+len(visibility.Robots[1].Seen) != len(visibility.Seen) {
+		t.Fatalf("expected robots on opposite edges not to see the same cells - got %s instead", visibility)
+	}
+	if len(visibility.Seen)+len(visibility.Unseen) != visibility.Cells {
+		t.Fatalf("expected every cell to be either seen or unseen - got %d seen and %d unseen instead", len(visibility.Seen), len(visibility.Unseen))
+	}
+
+	visibility = testVisibility(t, input, &Options{}, Camera{Range: 1, Angle: 90}, map[int]Camera{2: {Range: 2, Angle: 360}})
+	if !visibility.Complete() || visibility.Coverage() != 1 {
+		t.Fatalf("expected a complete view - got %v unseen instead", visibility.Unseen)
+	}
+}
+
+func TestMission_Visibility_OutOfBounds(t *testing.T) {
+	mission, _ := Simulate("5 5\n0 0 S\nM", nil)
+	visibility, err := mission.Visibility(Camera{Range: 1, Angle: 360}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if steps := visibility.Robots[0].Steps; len(steps) != 2 || len(steps[0]) != 3 || steps[1] != nil {
+		t.Fatalf("expected a lost robot to see nothing - got %v instead", steps)
+	}
+}
+
+func TestMission_Visibility_Hex(t *testing.T) {
+	visibility := testVisibility(t, "2\n0 0 E\nL", &Options{Grid: HexGrid}, Camera{Range: 1, Angle: 120}, nil)
+	expected := []Cell{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: -1}, {X: 1, Y: 0}}
+	if steps := visibility.Robots[0].Steps; !reflect.DeepEqual(steps[0], expected) {
+		t.Fatalf("expected %v to be seen - got %v instead", expected, steps[0])
+	}
+	if visibility.Cells != 19 {
+		t.Fatalf("expected a hexagon of radius 2 to hold 19 cells - got %d instead", visibility.Cells)
+	}
+}
+
+func TestMission_Visibility_InvalidCamera(t *testing.T) {
+	mission, _ := Simulate("5 5\n1 1 N\nM", nil)
+	tests := map[string]struct {
+		camera  Camera
+		cameras map[int]Camera
+	}{
+		"negative range": {Camera{Range: -1, Angle: 90}, nil},
+		"no angle":       {Camera{Range: 2}, nil},
+		"too wide":       {Camera{Range: 2, Angle: 361}, nil},
+		"robot":          {Camera{Range: 2, Angle: 90}, map[int]Camera{0: {Range: 2, Angle: -90}}},
+	}
+	for name, test := range tests {
+		_, err := mission.Visibility(test.camera, test.cameras)
+		if reflect.TypeOf(err) != reflect.TypeOf(&CameraError{}) {
+			t.Fatalf("%s: expected a CameraError - got %v instead", name, err)
+		}
+	}
+}
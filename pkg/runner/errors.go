@@ -1,6 +1,9 @@
 package runner
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // MissingInputLinesError is an error that is used whenever the number of instructions given
 // to the Run command is less than the expected amount.
@@ -22,8 +25,20 @@ func (e *EvenInputLinesError) Error() string {
 	return fmt.Sprintf("the input to this runner should have an odd number of lines - %d lines were detected", e.Lines)
 }
 
+// RobotInputLinesError is an error that is used whenever the input to the Run command holds only the
+// robot instructions, since a surface has been given, but does not have an even number of lines.
+// Each robot requires two lines of instructions, so there must be at least two lines.
+type RobotInputLinesError struct{ Lines int }
+
+// Error outputs a message relating to the number of robot instructions within the input.
+func (r *RobotInputLinesError) Error() string {
+	return fmt.Sprintf("the input to this runner should have an even number of lines, and at least %d lines, when a surface has been given - %d lines were detected", minimumInputLines-1, r.Lines)
+}
+
 // SurfaceDimensionError is an error that is thrown whenever a surface does not have two dimensions.
-// I.E the instruction-set 2 2 3 is incorrect since that would relate to a three-dimensional coordinate.
+// I.E the instruction-set 2 2 3 is incorrect since that would relate to a three-dimensional coordinate;
+// the elevation of a surface is instead given by a heightmap, see Options.Elevation. Whereas 2 2 and -2 -2 2 2 are both correct since the latter gives both the lower-left and upper-right
+// boundaries of the surface, as is 0 0 2 0 0 2 since it gives the vertices of a polygon.
 type SurfaceDimensionError struct {
 	Dimensions int
 	Surface    string
@@ -34,6 +49,61 @@ func (s *SurfaceDimensionError) Error() string {
 	return fmt.Sprintf("surface '%s' does not have the required number of surface dimensions - expected %d dimensions - detected %d instead", s.Surface, requiredSurfaceDimensions, s.Dimensions)
 }
 
+// GridError is an error that is returned whenever a mission is run upon an unknown grid.
+type GridError struct {
+	Grid Grid
+}
+
+// Error outputs a message relating to the unknown grid.
+func (g *GridError) Error() string {
+	return fmt.Sprintf("'%s' is not a known grid - expected '%s' or '%s'", g.Grid, SquareGrid, HexGrid)
+}
+
+// MotionError is an error that is returned whenever a stochastic motion model is invalid, or a
+// Monte-Carlo run is requested without one.
+type MotionError struct {
+	Reason string
+}
+
+// Error outputs a message relating to the invalid motion model.
+func (m *MotionError) Error() string {
+	return fmt.Sprintf("invalid motion model - %s", m.Reason)
+}
+
+// CameraError is an error that is returned whenever a camera is unable to see anything, because
+// its range is negative or its angle is not between 0 and 360 degrees. ID is the ID of the robot
+// whose camera it is, or nil for the camera of every other robot.
+type CameraError struct {
+	ID    *int
+	Range int
+	Angle float64
+}
+
+// Error outputs a message relating to the invalid camera.
+func (c *CameraError) Error() string {
+	camera := "the camera"
+	if c.ID != nil {
+		camera = fmt.Sprintf("the camera of robot ID %d", *c.ID)
+	}
+	return fmt.Sprintf("%s with a range of %d and an angle of %v is invalid - expected a range of at least 0 and an angle above 0 and at most 360", camera, c.Range, c.Angle)
+}
+
+// SpeedError is an error that is returned whenever a speed is less than a single cell. ID is the ID
+// of the robot whose speed it is, or nil for the speed of every other robot, which may also be zero.
+type SpeedError struct {
+	ID    *int
+	Speed int
+}
+
+// Error outputs a message relating to the invalid speed.
+func (s *SpeedError) Error() string {
+	speed := "the speed"
+	if s.ID != nil {
+		speed = fmt.Sprintf("the speed of robot ID %d", *s.ID)
+	}
+	return fmt.Sprintf("%s of %d is invalid - expected at least 1", speed, s.Speed)
+}
+
 // ParseSurfaceBoundaryError is an error that is used whenever a coordinate for a surface cannot be parsed
 // into an int.
 type ParseSurfaceBoundaryError struct {
@@ -53,7 +123,8 @@ func (s *ParseSurfaceBoundaryError) Unwrap() error {
 }
 
 // SurfaceError is an error that is returned whenever a surface is unable to be constructed.
-// It wraps any error returned from plateau.New.
+// It wraps any error returned from plateau.New, plateau.NewWithLowerBounds or plateau.NewPolygon,
+// or from adding a plateau or portal to a plateau.Atlas.
 type SurfaceError struct {
 	Err error
 }
@@ -118,16 +189,17 @@ func (p *ParseRobotDirectionError) Unwrap() error {
 }
 
 // RobotOutOfBoundsError is an error that is returned whenever a robot moves out of bounds
-// within a surface.
+// within a surface. Plateau is the name of the surface whenever a mission spans more than one.
 type RobotOutOfBoundsError struct {
-	ID int
-	X  int
-	Y  int
+	ID      int
+	Plateau string
+	X       int
+	Y       int
 }
 
 // Error outputs a message that relates to the out-of-bound position.
 func (r *RobotOutOfBoundsError) Error() string {
-	return fmt.Sprintf("robot ID %d has moved out of bounds - X: %d Y: %d", r.ID, r.X, r.Y)
+	return fmt.Sprintf("robot ID %d has moved out of bounds - %s", r.ID, location(r.Plateau, r.X, r.Y))
 }
 
 // ParseRobotMovementError is an error that is returned whenever a movement instruction is
@@ -147,3 +219,276 @@ func (p *ParseRobotMovementError) Error() string {
 func (p *ParseRobotMovementError) Unwrap() error {
 	return p.Err
 }
+
+// RobotCollisionError is an error that is returned whenever a robot is placed within a cell
+// that is already occupied by another robot. Plateau is the name of the surface whenever a
+// mission spans more than one.
+type RobotCollisionError struct {
+	ID      int
+	OtherID int
+	Plateau string
+	X       int
+	Y       int
+}
+
+// Error outputs a message that relates to the occupied cell.
+func (r *RobotCollisionError) Error() string {
+	return fmt.Sprintf("robot ID %d has collided with robot ID %d - %s", r.ID, r.OtherID, location(r.Plateau, r.X, r.Y))
+}
+
+// RobotClimbError is an error that is returned whenever a robot would move into a cell whose
+// change in elevation is steeper than the robot is able to climb or descend. Climb is the change
+// in elevation, which is negative for a descent, and Max is the robot's limit.
+type RobotClimbError struct {
+	ID      int
+	Plateau string
+	X       int
+	Y       int
+	Climb   int
+	Max     int
+}
+
+// Error outputs a message that relates to the move that is too steep.
+func (r *RobotClimbError) Error() string {
+	if r.Climb < 0 {
+		return fmt.Sprintf("robot ID %d is unable to descend %d to reach %s - the limit is %d", r.ID, -r.Climb, location(r.Plateau, r.X, r.Y), r.Max)
+	}
+	return fmt.Sprintf("robot ID %d is unable to climb %d to reach %s - the limit is %d", r.ID, r.Climb, location(r.Plateau, r.X, r.Y), r.Max)
+}
+
+// location outputs a coordinate, preceded by the name of its plateau whenever it has one.
+func location(plateau string, x, y int) string {
+	if plateau != "" {
+		return fmt.Sprintf("Plateau: %s X: %d Y: %d", plateau, x, y)
+	}
+	return fmt.Sprintf("X: %d Y: %d", x, y)
+}
+
+// PlateauInstructionError is an error that is returned whenever an instruction describing a
+// plateau or a portal does not have the required number of instructions, or holds a direction
+// that is unable to be parsed.
+type PlateauInstructionError struct {
+	Instruction string
+	Err         error
+}
+
+// Error outputs a message that relates to the invalid instruction.
+func (p *PlateauInstructionError) Error() string {
+	if p.Err != nil {
+		return fmt.Sprintf("'%s' does not describe a valid plateau or portal: %v", p.Instruction, p.Err)
+	}
+	return fmt.Sprintf("'%s' does not describe a valid plateau or portal", p.Instruction)
+}
+
+// Unwrap returns the error that is contained within the PlateauInstructionError, if any.
+func (p *PlateauInstructionError) Unwrap() error {
+	return p.Err
+}
+
+// UnknownPlateauError is an error that is returned whenever a robot is placed upon a plateau
+// that the mission does not describe.
+type UnknownPlateauError struct {
+	ID      int
+	Plateau string
+}
+
+// Error outputs a message that relates to the unknown plateau.
+func (u *UnknownPlateauError) Error() string {
+	return fmt.Sprintf("robot ID %d cannot be placed upon unknown plateau '%s'", u.ID, u.Plateau)
+}
+
+// RobotDeadlockError is an error that is returned whenever every robot that has commands
+// remaining is waiting on a cell occupied by another robot, so none of them can ever move.
+type RobotDeadlockError struct {
+	IDs []int
+}
+
+// Error outputs a message that relates to the robots that are deadlocked.
+func (r *RobotDeadlockError) Error() string {
+	return fmt.Sprintf("robot IDs %v are deadlocked waiting on one another", r.IDs)
+}
+
+// RobotTimeoutError is an error that is returned whenever a robot is unable to carry out
+// a command before the mission's time limit has passed.
+type RobotTimeoutError struct {
+	ID       int
+	Movement string
+	Time     time.Duration
+	Limit    time.Duration
+}
+
+// Error outputs a message that relates to the command that could not be carried out in time.
+func (r *RobotTimeoutError) Error() string {
+	return fmt.Sprintf("robot ID %d is unable to carry out movement %s at %v before the time limit of %v", r.ID, r.Movement, r.Time, r.Limit)
+}
+
+// CancelledError is an error that is returned whenever a run is stopped because its context
+// has been cancelled. It wraps the error returned by the context.
+type CancelledError struct {
+	Err error
+}
+
+// Error outputs a simple message stating the run was cancelled.
+func (c *CancelledError) Error() string {
+	return fmt.Sprintf("run was cancelled: %v", c.Err)
+}
+
+// Unwrap returns the error that is contained within CancelledError.
+func (c *CancelledError) Unwrap() error {
+	return c.Err
+}
+
+// Limit names a resource that a mission is limited in using; see runner.Limits.
+type Limit string
+
+const (
+	// AreaLimit relates to the number of cells within a surface.
+	AreaLimit Limit = "area"
+	// RobotsLimit relates to the number of robots within a mission.
+	RobotsLimit Limit = "robots"
+	// CommandsLimit relates to the number of commands given to a single robot.
+	CommandsLimit Limit = "commands"
+	// StepsLimit relates to the number of steps taken by every robot within a mission.
+	StepsLimit Limit = "steps"
+)
+
+// LimitError is an error that is returned whenever a mission breaks one of its limits.
+type LimitError struct {
+	Limit Limit
+	Max   int
+}
+
+// Error outputs a message that relates to the limit that was broken.
+func (l *LimitError) Error() string {
+	return fmt.Sprintf("the mission has exceeded the %s limit of %d", l.Limit, l.Max)
+}
+
+// DuplicateRobotError is an error that is returned whenever a robot is deployed within a
+// session under a name that is already in use.
+type DuplicateRobotError struct {
+	Name string
+}
+
+// Error outputs a message that relates to the name already in use.
+func (d *DuplicateRobotError) Error() string {
+	return fmt.Sprintf("a robot named '%s' has already been deployed", d.Name)
+}
+
+// UnknownRobotError is an error that is returned whenever commands are sent to a robot that
+// has not been deployed within a session.
+type UnknownRobotError struct {
+	Name string
+}
+
+// Error outputs a message that relates to the unknown robot.
+func (u *UnknownRobotError) Error() string {
+	return fmt.Sprintf("no robot named '%s' has been deployed", u.Name)
+}
+
+// RobotLostError is an error that is returned whenever commands are sent to a robot within
+// a session that has moved out of bounds.
+type RobotLostError struct {
+	Name string
+	ID   int
+}
+
+// Error outputs a message that relates to the lost robot.
+func (r *RobotLostError) Error() string {
+	return fmt.Sprintf("robot '%s' with ID %d has been lost after moving out of bounds", r.Name, r.ID)
+}
+
+// SnapshotError is an error that is returned whenever a snapshot cannot be read or restored.
+type SnapshotError struct {
+	Reason string
+}
+
+// Error outputs a message that relates to why the snapshot is invalid.
+func (s *SnapshotError) Error() string {
+	return fmt.Sprintf("invalid snapshot: %s", s.Reason)
+}
+
+// RecordedError is an error that is returned whenever a journal being replayed recorded an
+// error that cannot be rebuilt as its original type. ID is the robot that raised the error,
+// if any.
+type RecordedError struct {
+	ID      *int
+	Message string
+}
+
+// Error outputs the message of the recorded error.
+func (r *RecordedError) Error() string {
+	return r.Message
+}
+
+// ReplayDivergenceError is an error that is returned whenever a journal being replayed does
+// not match the mission rebuilt from it.
+type ReplayDivergenceError struct {
+	Line   int
+	Seq    int
+	Reason string
+}
+
+// Error outputs a message that relates to where and why the replay diverged.
+func (r *ReplayDivergenceError) Error() string {
+	return fmt.Sprintf("replay diverged from the journal on line %d (event %d): %s", r.Line, r.Seq, r.Reason)
+}
+
+// DeclarationError is an error that is returned whenever an instruction declaring a type of rover
+// or placing an obstacle does not have the required number of instructions, or holds a value that
+// is unable to be parsed.
+type DeclarationError struct {
+	Instruction string
+	Err         error
+}
+
+// Error outputs a message that relates to the invalid instruction.
+func (d *DeclarationError) Error() string {
+	if d.Err != nil {
+		return fmt.Sprintf("'%s' does not declare a valid type or obstacle: %v", d.Instruction, d.Err)
+	}
+	return fmt.Sprintf("'%s' does not declare a valid type or obstacle", d.Instruction)
+}
+
+// Unwrap returns the error that is contained within the DeclarationError, if any.
+func (d *DeclarationError) Unwrap() error {
+	return d.Err
+}
+
+// UnknownTypeError is an error that is returned whenever a robot is given a type that the
+// mission does not declare.
+type UnknownTypeError struct {
+	ID   int
+	Type string
+}
+
+// Error outputs a message that relates to the unknown type.
+func (u *UnknownTypeError) Error() string {
+	return fmt.Sprintf("robot ID %d cannot be of unknown type '%s'", u.ID, u.Type)
+}
+
+// RobotCommandError is an error that is returned whenever a robot is given a command that its
+// type is unable to carry out.
+type RobotCommandError struct {
+	ID       int
+	Type     string
+	Movement string
+}
+
+// Error outputs a message that relates to the command the robot is unable to carry out.
+func (r *RobotCommandError) Error() string {
+	return fmt.Sprintf("robot ID %d of type '%s' is unable to carry out the command '%s'", r.ID, r.Type, r.Movement)
+}
+
+// RobotObstacleError is an error that is returned whenever a robot would be placed upon or move
+// into a cell holding an obstacle that it is unable to cross.
+type RobotObstacleError struct {
+	ID      int
+	Plateau string
+	X       int
+	Y       int
+}
+
+// Error outputs a message that relates to the obstacle blocking the robot.
+func (r *RobotObstacleError) Error() string {
+	return fmt.Sprintf("robot ID %d is blocked by an obstacle - %s", r.ID, location(r.Plateau, r.X, r.Y))
+}
@@ -0,0 +1,199 @@
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/robot"
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+const (
+	// typeKeyword begins an instruction that declares a type of rover.
+	typeKeyword = "type"
+	// obstacleKeyword begins an instruction that places an obstacle upon a surface.
+	obstacleKeyword = "obstacle"
+	// typeInstructionLength is the number of instructions required to declare a type of rover,
+	// excluding the type keyword.
+	typeInstructionLength = 5
+	// crossObstacles marks a type of rover that can move into a cell holding an obstacle,
+	// whereas avoidObstacles marks one that cannot.
+	crossObstacles = "cross"
+	avoidObstacles = "avoid"
+)
+
+// Obstacle is a cell holding an obstacle, which blocks any robot whose type is unable to cross
+// obstacles. Plateau is the name of the plateau it lies upon whenever a mission spans more than one.
+type Obstacle struct {
+	Plateau string
+	X       int
+	Y       int
+}
+
+// isDeclaration checks if an instruction declares a type of rover or places an obstacle.
+func isDeclaration(s string) bool {
+	keyword := strings.Split(strings.TrimSpace(s), " ")[0]
+	return keyword == typeKeyword || keyword == obstacleKeyword
+}
+
+// declarations returns the number of instructions at the start of the given lines that declare
+// a type of rover or place an obstacle.
+func declarations(lines []string) int {
+	n := 0
+	for n < len(lines) && isDeclaration(lines[n]) {
+		n++
+	}
+	return n
+}
+
+// declare adds each type of rover and obstacle declared by the given instructions to the mission,
+// E.G "type scout 2 LRM avoid 500ms" and "obstacle 2 3".
+func (m *Mission) declare(lines []string) error {
+	for _, line := range lines {
+		config := strings.Split(strings.TrimSpace(line), " ")
+		if config[0] == typeKeyword {
+			t, err := buildType(line, config[1:])
+			if err != nil {
+				return err
+			}
+			if _, ok := m.Types[t.Name]; ok {
+				return &DeclarationError{Instruction: line, Err: fmt.Errorf("type '%s' has already been declared", t.Name)}
+			}
+			if m.Types == nil {
+				m.Types = make(map[string]*robot.Type)
+			}
+			m.Types[t.Name] = t
+			continue
+		}
+		o, err := m.buildObstacle(line, config[1:])
+		if err != nil {
+			return err
+		}
+		if m.Obstacles == nil {
+			m.Obstacles = make(map[Obstacle]bool)
+		}
+		m.Obstacles[o] = true
+	}
+	return nil
+}
+
+// buildType constructs a robot.Type given the instructions that follow the type keyword, which
+// hold its name, the number of cells each move carries it, the commands it can carry out, whether
+// it can cross obstacles and how long it takes to turn.
+func buildType(s string, config []string) (*robot.Type, error) {
+	if len(config) != typeInstructionLength {
+		return nil, &DeclarationError{Instruction: s}
+	}
+	step, err := strconv.Atoi(config[1])
+	if err != nil {
+		return nil, &DeclarationError{Instruction: s, Err: err}
+	}
+	if step < 1 {
+		return nil, &DeclarationError{Instruction: s, Err: fmt.Errorf("a step of %d is not at least 1", step)}
+	}
+	var commands []travel.Movement
+	for _, c := range config[2] {
+		move, err := travel.ParseMovement(string(c))
+		if err != nil {
+			return nil, &DeclarationError{Instruction: s, Err: err}
+		}
+		commands = append(commands, move)
+	}
+	if config[3] != crossObstacles && config[3] != avoidObstacles {
+		return nil, &DeclarationError{Instruction: s, Err: fmt.Errorf("'%s' is neither '%s' nor '%s'", config[3], crossObstacles, avoidObstacles)}
+	}
+	turn, err := time.ParseDuration(config[4])
+	if err != nil {
+		return nil, &DeclarationError{Instruction: s, Err: err}
+	}
+	return &robot.Type{
+		Name:             config[0],
+		Step:             step,
+		Commands:         commands,
+		CrossesObstacles: config[3] == crossObstacles,
+		Turn:             turn,
+	}, nil
+}
+
+// buildObstacle constructs an Obstacle given the instructions that follow the obstacle keyword,
+// which hold its coordinate, preceded by its plateau whenever the mission spans more than one.
+// An obstacle without a plateau is placed upon the first.
+func (m *Mission) buildObstacle(s string, config []string) (Obstacle, error) {
+	var name string
+	if m.Atlas != nil {
+		name = m.Atlas.Names()[0]
+		if len(config) == requiredSurfaceDimensions+1 {
+			name, config = config[0], config[1:]
+		}
+	}
+	if len(config) != requiredSurfaceDimensions {
+		return Obstacle{}, &DeclarationError{Instruction: s}
+	}
+	x, err := strconv.Atoi(config[0])
+	if err != nil {
+		return Obstacle{}, &DeclarationError{Instruction: s, Err: err}
+	}
+	y, err := strconv.Atoi(config[1])
+	if err != nil {
+		return Obstacle{}, &DeclarationError{Instruction: s, Err: err}
+	}
+	surface := m.surfaceOf(name)
+	if surface == nil {
+		return Obstacle{}, &DeclarationError{Instruction: s, Err: fmt.Errorf("'%s' is not a known plateau", name)}
+	}
+	if surface.IsOutOfBounds(x, y) {
+		return Obstacle{}, &DeclarationError{Instruction: s, Err: fmt.Errorf("%s is out of bounds", location(name, x, y))}
+	}
+	return Obstacle{Plateau: name, X: x, Y: y}, nil
+}
+
+// obstacles returns every obstacle within the mission, ordered by plateau and then by coordinate.
+func (m *Mission) obstacles() []Obstacle {
+	obstacles := make([]Obstacle, 0, len(m.Obstacles))
+	for o := range m.Obstacles {
+		obstacles = append(obstacles, o)
+	}
+	sort.Slice(obstacles, func(i, j int) bool {
+		a, b := obstacles[i], obstacles[j]
+		if a.Plateau != b.Plateau {
+			return a.Plateau < b.Plateau
+		}
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Y < b.Y
+	})
+	return obstacles
+}
+
+// typeName returns the name of a robot's type, or the empty string if it has none.
+func typeName(r *robot.Robot) string {
+	if t := r.GetType(); t != nil {
+		return t.Name
+	}
+	return ""
+}
+
+// checkCommand returns a RobotCommandError whenever the robot's type is unable to carry out the
+// given movement. A robot without a type can carry out any movement.
+func (m *manager) checkCommand(move travel.Movement) error {
+	if t := m.robot.GetType(); t != nil && !t.Can(move) {
+		return &RobotCommandError{ID: m.robot.GetID(), Type: t.Name, Movement: string(move)}
+	}
+	return nil
+}
+
+// checkObstacle returns a RobotObstacleError whenever the given cell holds an obstacle that the
+// robot is unable to cross. Only a robot whose type crosses obstacles can move into one.
+func (m *manager) checkObstacle(to cell) error {
+	if !m.mission.Obstacles[Obstacle{Plateau: to.plateau, X: to.x, Y: to.y}] {
+		return nil
+	}
+	if t := m.robot.GetType(); t != nil && t.CrossesObstacles {
+		return nil
+	}
+	return &RobotObstacleError{ID: m.robot.GetID(), Plateau: to.plateau, X: to.x, Y: to.y}
+}
@@ -0,0 +1,93 @@
+package runner
+
+import (
+	"math"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// Grid is the topology of the cells that a mission's robots move across.
+type Grid string
+
+const (
+	// SquareGrid is a grid of square cells, where each robot faces one of N E S W and turns by
+	// 90 degrees; see travel.Travel. The zero value of Grid is a SquareGrid.
+	SquareGrid Grid = "square"
+	// HexGrid is a grid of pointy-topped hexes using axial coordinates, where each robot faces
+	// one of E SE SW W NW NE and turns by 60 degrees; see travel.HexTravel.
+	HexGrid Grid = "hex"
+)
+
+// check returns a GridError if the grid is not a known topology.
+func (g Grid) check() error {
+	switch g {
+	case "", SquareGrid, HexGrid:
+		return nil
+	}
+	return &GridError{Grid: g}
+}
+
+// parseDirection parses the direction a robot is facing upon the grid.
+func (g Grid) parseDirection(s string) (travel.Direction, error) {
+	if g == HexGrid {
+		return travel.ParseHexDirection(s)
+	}
+	return travel.ParseDirection(s)
+}
+
+// travel returns the vector and direction of a robot carrying out a movement upon the grid.
+func (g Grid) travel(direction travel.Direction, move travel.Movement) (int, int, travel.Direction) {
+	if g == HexGrid {
+		return travel.HexTravel(direction, move)
+	}
+	return travel.Travel(direction, move)
+}
+
+// centre returns the position of the centre of a cell upon the plane, where neighbouring cells
+// are one unit apart. Each row of a hex grid is shifted half a hex to the right of the row
+// beneath it.
+func (g Grid) centre(x, y int) (float64, float64) {
+	if g == HexGrid {
+		return float64(x) + float64(y)/2, float64(y) * math.Sqrt(3) / 2
+	}
+	return float64(x), float64(y)
+}
+
+// cellAt returns the cell whose centre is nearest to a position upon the plane; see centre.
+func (g Grid) cellAt(px, py float64) (int, int) {
+	if g != HexGrid {
+		return int(math.Round(px)), int(math.Round(py))
+	}
+	// convert to fractional cube coordinates and round the component that is furthest from
+	// a whole number from the other two.
+	fy := py * 2 / math.Sqrt(3)
+	fx := px - fy/2
+	fz := -fx - fy
+	x, y, z := math.Round(fx), math.Round(fy), math.Round(fz)
+	dx, dy, dz := math.Abs(x-fx), math.Abs(y-fy), math.Abs(z-fz)
+	switch {
+	case dx > dy && dx > dz:
+		x = -y - z
+	case dy > dz:
+		y = -x - z
+	}
+	return int(x), int(y)
+}
+
+// distance returns the distance between two cells that are the given number of cells apart,
+// which upon a square grid is between their centres and upon a hex grid is the number of hexes
+// between them.
+func (g Grid) distance(dx, dy int) float64 {
+	if g == HexGrid {
+		return float64(abs(dx)+abs(dy)+abs(dx+dy)) / 2
+	}
+	return math.Hypot(float64(dx), float64(dy))
+}
+
+// abs returns the absolute value of an int.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
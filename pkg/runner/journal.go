@@ -0,0 +1,362 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+	"github.com/juubisnake/mars-rover/internal/pkg/robot"
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// EventType is the kind of action an Event records.
+type EventType string
+
+const (
+	// SurfaceCreated records the surface a mission or session takes place upon, or one of the
+	// named plateaus whenever a mission spans more than one.
+	SurfaceCreated EventType = "surface_created"
+	// PortalLinked records a portal between two of a mission's plateaus.
+	PortalLinked EventType = "portal_linked"
+	// ObstaclePlaced records an obstacle placed upon one of a mission's surfaces.
+	ObstaclePlaced EventType = "obstacle_placed"
+	// RobotDeployed records a robot being placed upon the surface.
+	RobotDeployed EventType = "robot_deployed"
+	// CommandExecuted records a robot carrying out a single command.
+	CommandExecuted EventType = "command_executed"
+	// RobotFinished records a robot carrying out all of its commands.
+	RobotFinished EventType = "robot_finished"
+	// ErrorRaised records an error that halted a mission or was returned by a session.
+	ErrorRaised EventType = "error_raised"
+)
+
+// Event is a single action recorded within a journal. Seq is the order of the event within
+// the journal, starting at 1, and Time is the time of the robot's clock once the action had
+// been carried out, in nanoseconds. Rover is the type of a deployed robot, if it has one, and
+// Speed is the number of cells each of its moves carries it whenever that is more than one.
+// Slip is the way an executed command went awry, if at all; see Motion. Which of the remaining
+// fields are set depends upon the type of the event.
+type Event struct {
+	Seq      int               `json:"seq"`
+	Type     EventType         `json:"type"`
+	Surface  *SnapshotSurface  `json:"surface,omitempty"`
+	Portal   *SnapshotPortal   `json:"portal,omitempty"`
+	Obstacle *SnapshotObstacle `json:"obstacle,omitempty"`
+	ID       *int              `json:"id,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Rover    *SnapshotType     `json:"rover,omitempty"`
+	Speed    int               `json:"speed,omitempty"`
+	Command  string            `json:"command,omitempty"`
+	Slip     Slip              `json:"slip,omitempty"`
+	Before   *SnapshotPose     `json:"before,omitempty"`
+	After    *SnapshotPose     `json:"after,omitempty"`
+	Time     time.Duration     `json:"time,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// Journal writes every action taken within a mission or session to a writer as JSON Lines,
+// one Event per line. A Journal is safe for concurrent use.
+type Journal struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	seq int
+	err error
+}
+
+// NewJournal creates a journal that writes its events to a given writer.
+func NewJournal(w io.Writer) *Journal {
+	return &Journal{enc: json.NewEncoder(w)}
+}
+
+// Record appends an event to the journal, numbering it in the order it was recorded.
+// Once writing an event has failed no further events are written; see Err.
+func (j *Journal) Record(e Event) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return
+	}
+	j.seq++
+	e.Seq = j.seq
+	j.err = j.enc.Encode(e)
+}
+
+// Err returns the error that stopped the journal from writing its events, if any.
+func (j *Journal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// recordMission records the creation of the surface a mission or session takes place upon, or
+// of every plateau and portal whenever the mission spans more than one, followed by every obstacle.
+func (j *Journal) recordMission(m *Mission) {
+	if m.Atlas == nil {
+		surface := snapshotSurface(m.Surface, m.Elevation[""], m.Grid)
+		j.Record(Event{Type: SurfaceCreated, Surface: &surface})
+	} else {
+		for _, name := range m.Atlas.Names() {
+			surface := snapshotSurface(m.Atlas.Surface(name), m.Elevation[name], m.Grid)
+			j.Record(Event{Type: SurfaceCreated, Name: name, Surface: &surface})
+		}
+		for _, p := range m.Atlas.Portals() {
+			portal := snapshotPortal(p)
+			j.Record(Event{Type: PortalLinked, Portal: &portal})
+		}
+	}
+	for _, o := range m.obstacles() {
+		obstacle := SnapshotObstacle(o)
+		j.Record(Event{Type: ObstaclePlaced, Obstacle: &obstacle})
+	}
+}
+
+// recordError records an error raised by the robot with the given ID, or by no robot in
+// particular when the ID is nil.
+func (j *Journal) recordError(id *int, err error) {
+	j.Record(Event{Type: ErrorRaised, ID: id, Error: err.Error()})
+}
+
+// recordDeploy records the manager's robot being placed upon the surface.
+func (m *manager) recordDeploy(name string) {
+	id := m.robot.GetID()
+	after := snapshotPose(poseOf(m.robot))
+	var rover *SnapshotType
+	if t := m.robot.GetType(); t != nil {
+		snapshot := snapshotType(t)
+		rover = &snapshot
+	}
+	var speed int
+	if m.speed > 1 {
+		speed = m.speed
+	}
+	m.opts.Journal.Record(Event{Type: RobotDeployed, ID: &id, Name: name, Rover: rover, Speed: speed, After: &after, Time: m.clock})
+}
+
+// recordFinish records the manager's robot having carried out all of its commands.
+func (m *manager) recordFinish() {
+	id := m.robot.GetID()
+	m.opts.Journal.Record(Event{Type: RobotFinished, ID: &id, Time: m.clock})
+}
+
+// recordCommand records the manager's robot carrying out a command from a given pose, along with
+// the way the command went awry, if at all.
+func (m *manager) recordCommand(move travel.Movement, before Pose, slip Slip) {
+	id := m.robot.GetID()
+	from, after := snapshotPose(before), snapshotPose(poseOf(m.robot))
+	m.opts.Journal.Record(Event{Type: CommandExecuted, ID: &id, Command: string(move), Slip: slip, Before: &from, After: &after, Time: m.clock})
+}
+
+// recordMissionError records the error that halted a mission, along with the ID of the robot
+// that raised it if the robot had been placed upon the surface.
+func recordMissionError(j *Journal, mission *Mission, err error) {
+	if mission != nil {
+		for _, t := range mission.Tracks {
+			if t.Err == err {
+				id := t.ID
+				j.recordError(&id, err)
+				return
+			}
+		}
+	}
+	j.recordError(nil, err)
+}
+
+// robotManager returns the manager of the robot with the given ID, if the ID is not nil and the
+// robot has been deployed.
+func robotManager(managers map[int]*manager, id *int) (*manager, bool) {
+	if id == nil {
+		return nil, false
+	}
+	m, ok := managers[*id]
+	return m, ok
+}
+
+// Replay rebuilds a mission from a journal written by a mission or session, repeating every
+// recorded command using the same travel semantics as Run and checking that each robot ends
+// up where the journal says it did.
+//
+// Replay returns the rebuilt mission AND the last error recorded within the journal, if any; a
+// RobotOutOfBoundsError is rebuilt as such, whereas any other error is returned as a
+// RecordedError. Since a session's robots carry on after any error other than moving out of
+// bounds, a robot that carries out a command after such an error is no longer halted by it.
+// If the journal does not match the replayed mission, Replay returns the mission up until the
+// point of divergence AND a ReplayDivergenceError.
+func Replay(r io.Reader) (*Mission, error) {
+	var mission *Mission
+	managers := make(map[int]*manager)
+	var recorded error
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return mission, &ReplayDivergenceError{Line: line, Reason: err.Error()}
+		}
+		diverged := func(format string, a ...interface{}) error {
+			return &ReplayDivergenceError{Line: line, Seq: e.Seq, Reason: fmt.Sprintf(format, a...)}
+		}
+		if e.Type == ErrorRaised {
+			recorded = &RecordedError{ID: e.ID, Message: e.Error}
+			if m, ok := robotManager(managers, e.ID); ok {
+				if m.track.Err != nil && m.track.Err.Error() == e.Error {
+					recorded = m.track.Err
+				} else if m.track.Err == nil {
+					m.track.Err = recorded
+				}
+			}
+			if mission != nil {
+				mission.Err = recorded
+			}
+			continue
+		}
+		if e.Type == SurfaceCreated {
+			if e.Surface == nil || mission != nil && (e.Name == "" || mission.Atlas == nil || len(mission.Tracks) > 0) {
+				return mission, diverged("unexpected surface")
+			}
+			surface, heightmap, err := restoreSurface(*e.Surface)
+			if err != nil {
+				return mission, diverged("%s", err)
+			}
+			if err := e.Surface.Grid.check(); err != nil {
+				return mission, diverged("%s", err)
+			}
+			if mission == nil {
+				mission = &Mission{Surface: surface, Grid: e.Surface.Grid}
+				if e.Name != "" {
+					mission.Atlas = plateau.NewAtlas()
+				}
+			}
+			if heightmap != nil {
+				if mission.Elevation == nil {
+					mission.Elevation = make(map[string]*plateau.Heightmap)
+				}
+				mission.Elevation[e.Name] = heightmap
+			}
+			if mission.Atlas != nil {
+				if err := mission.Atlas.Add(e.Name, surface); err != nil {
+					return mission, diverged("%s", err)
+				}
+			}
+			continue
+		}
+		if e.Type == PortalLinked {
+			if mission == nil || mission.Atlas == nil || e.Portal == nil {
+				return mission, diverged("unexpected portal")
+			}
+			portal, err := restorePortal(mission.Grid, *e.Portal)
+			if err == nil {
+				err = mission.Atlas.Link(portal)
+			}
+			if err != nil {
+				return mission, diverged("%s", err)
+			}
+			continue
+		}
+		if e.Type == ObstaclePlaced {
+			if mission == nil || len(mission.Tracks) > 0 || e.Obstacle == nil {
+				return mission, diverged("unexpected obstacle")
+			}
+			o := Obstacle(*e.Obstacle)
+			if surface := mission.surfaceOf(o.Plateau); surface == nil || surface.IsOutOfBounds(o.X, o.Y) {
+				return mission, diverged("obstacle at %s is out of bounds", location(o.Plateau, o.X, o.Y))
+			}
+			if mission.Obstacles == nil {
+				mission.Obstacles = make(map[Obstacle]bool)
+			}
+			mission.Obstacles[o] = true
+			continue
+		}
+		if mission == nil || e.ID == nil {
+			return mission, diverged("event %s before the surface was created", e.Type)
+		}
+		m, ok := managers[*e.ID]
+		switch e.Type {
+		case RobotDeployed:
+			if ok {
+				return mission, diverged("robot %d has already been deployed", *e.ID)
+			}
+			if e.After == nil {
+				return mission, diverged("robot %d was deployed without a pose", *e.ID)
+			}
+			pose, err := restorePose(mission.Grid, *e.ID, *e.After)
+			if err != nil {
+				return mission, diverged("%s", err)
+			}
+			m = &manager{surface: mission.Surface, atlas: mission.Atlas, mission: mission, robot: robot.NewOn(*e.ID, pose.Plateau, pose.X, pose.Y, pose.Direction), clock: e.Time}
+			if surface := m.currentSurface(); surface == nil || surface.IsOutOfBounds(pose.X, pose.Y) {
+				return mission, diverged("robot %d was deployed out of bounds at %s", *e.ID, pose)
+			}
+			if e.Rover != nil {
+				kind, err := restoreType(*e.Rover)
+				if err != nil {
+					return mission, diverged("%s", err)
+				}
+				if known := mission.Types[kind.Name]; known != nil {
+					kind = known
+				} else {
+					if mission.Types == nil {
+						mission.Types = make(map[string]*robot.Type)
+					}
+					mission.Types[kind.Name] = kind
+				}
+				m.robot.SetType(kind)
+			}
+			m.speed = e.Speed
+			m.track = &Track{ID: *e.ID, Type: typeName(m.robot), Start: pose, Placed: mission.taken, Started: e.Time}
+			mission.Tracks = append(mission.Tracks, m.track)
+			managers[*e.ID] = m
+		case CommandExecuted:
+			if !ok {
+				return mission, diverged("robot %d has not been deployed", *e.ID)
+			}
+			if _, lost := m.track.Err.(*RobotOutOfBoundsError); lost {
+				return mission, diverged("robot %d carried out a command after moving out of bounds", *e.ID)
+			}
+			m.track.Err = nil
+			if e.Before == nil || e.After == nil {
+				return mission, diverged("command for robot %d is missing its poses", *e.ID)
+			}
+			if before := poseOf(m.robot); snapshotPose(before) != *e.Before {
+				return mission, diverged("robot %d was at %s rather than %s", *e.ID, before, *e.Before)
+			}
+			move, err := m.parseMovement(e.Command)
+			if err != nil {
+				return mission, diverged("%s", err)
+			}
+			slip, err := parseSlip(string(e.Slip))
+			if err != nil {
+				return mission, diverged("%s", err)
+			}
+			from := cellOf(m.robot)
+			m.follow(m.path(move, slip))
+			m.clock = e.Time
+			mission.taken++
+			climb := mission.heightOf(cellOf(m.robot)) - mission.heightOf(from)
+			m.track.Steps = append(m.track.Steps, Step{Command: move, Pose: poseOf(m.robot), Seq: mission.taken, Time: m.clock, Climb: climb, Slip: slip})
+			if after := poseOf(m.robot); snapshotPose(after) != *e.After {
+				return mission, diverged("robot %d moved to %s rather than %s", *e.ID, after, *e.After)
+			}
+			if m.currentSurface().IsOutOfBounds(m.robot.GetX(), m.robot.GetY()) {
+				m.track.Err = &RobotOutOfBoundsError{ID: *e.ID, Plateau: m.robot.GetPlateau(), X: m.robot.GetX(), Y: m.robot.GetY()}
+			}
+		case RobotFinished:
+			if !ok {
+				return mission, diverged("robot %d has not been deployed", *e.ID)
+			}
+			m.track.Done = true
+		default:
+			return mission, diverged("unknown event type '%s'", e.Type)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return mission, err
+	}
+	return mission, recorded
+}
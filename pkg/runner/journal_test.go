@@ -0,0 +1,180 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+)
+
+func testJournal(t *testing.T, input string, opts *Options) (*Mission, *bytes.Buffer) {
+	var buf bytes.Buffer
+	opts.Journal = NewJournal(&buf)
+	mission, _ := Simulate(input, opts)
+	if err := opts.Journal.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return mission, &buf
+}
+
+func testReplay(t *testing.T, expected *Mission, journal *bytes.Buffer) {
+	replayed, err := Replay(journal)
+	if !reflect.DeepEqual(err, expected.Err) {
+		t.Fatalf("expected the replay to return %v - got %v instead", expected.Err, err)
+	}
+	if !reflect.DeepEqual(replayed, expected) {
+		t.Fatalf("expected the replayed mission to match - got %s instead of %s", replayed, expected)
+	}
+}
+
+func TestReplay_Sequential(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLMLMLMM
+3 3 E
+MMRMMRMRRM
+0 0 S
+M`
+	mission, journal := testJournal(t, input, &Options{})
+	testReplay(t, mission, journal)
+}
+
+func TestReplay_Simultaneous(t *testing.T) {
+	input := `
+2 2
+0 1 E
+MM
+1 0 N
+MM`
+	mission, journal := testJournal(t, input, &Options{Simultaneous: true})
+	testReplay(t, mission, journal)
+}
+
+func TestReplay_RecordedError(t *testing.T) {
+	mission, journal := testJournal(t, "5 5\n1 1 N\nMMX", &Options{})
+	_, err := Replay(journal)
+	var recorded *RecordedError
+	if !errors.As(err, &recorded) || recorded.Message != mission.Err.Error() || *recorded.ID != 0 {
+		t.Fatalf("expected the recorded error to be returned - got %v instead", err)
+	}
+}
+
+func TestReplay_Session(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := NewSession("5 5", &Options{Journal: NewJournal(&buf)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Deploy("spirit", "1 1 E")
+	s.Deploy("opportunity", "3 1 W")
+	s.Command("opportunity", "MMM")
+	s.Command("opportunity", "RM")
+	replayed, err := Replay(&buf)
+	var recorded *RecordedError
+	if !errors.As(err, &recorded) {
+		t.Fatalf("expected the collision to be recorded - got %v instead", err)
+	}
+	if replayed.Tracks[1].End().String() != "2 2 N" || replayed.Tracks[1].Err != nil {
+		t.Fatalf("expected the robot to carry on after the collision to 2 2 N - got %s instead", replayed.Tracks[1].End())
+	}
+}
+
+func TestReplay_Divergence(t *testing.T) {
+	_, journal := testJournal(t, "5 5\n1 2 N\nLMLM", &Options{})
+	lines := strings.Split(journal.String(), "\n")
+	lines[4] = strings.Replace(lines[4], `"after":{"x":0,"y":2`, `"after":{"x":0,"y":3`, 1)
+	replayed, err := Replay(strings.NewReader(strings.Join(lines, "\n")))
+	var divergence *ReplayDivergenceError
+	if !errors.As(err, &divergence) || divergence.Line != 5 || divergence.Seq != 5 {
+		t.Fatalf("expected the replay to diverge on line 5 - got %v instead", err)
+	}
+	if replayed.Steps() != 3 {
+		t.Fatalf("expected the replay to stop at the divergent step - got %d steps instead", replayed.Steps())
+	}
+}
+
+func TestReplay_Plateaus(t *testing.T) {
+	input := `
+plateau alpha 3 3
+plateau beta -2 -2 2 2
+portal alpha 3 1 beta -2 0 E
+1 1 E
+MMMM
+beta 0 2 N
+M`
+	mission, journal := testJournal(t, input, &Options{})
+	testReplay(t, mission, journal)
+}
+
+func TestReplay_Elevation(t *testing.T) {
+	heightmap, err := plateau.NewHeightmap(0, 0, [][]int{{0, 4}, {1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `
+plateau alpha 1 1
+plateau beta 1 1
+portal alpha 1 0 beta 0 0 N
+0 0 E
+MM`
+	mission, journal := testJournal(t, input, &Options{Elevation: map[string]*plateau.Heightmap{"beta": heightmap}})
+	if mission.String() != "beta 0 1 N +1 -1" {
+		t.Fatalf("expected the robot to climb onto beta - got %s instead", mission.String())
+	}
+	testReplay(t, mission, journal)
+}
+
+func TestReplay_Hex(t *testing.T) {
+	input := `
+plateau alpha 1
+plateau beta -1 -1 1 1
+portal alpha 1 -1 beta -1 0 NE
+0 0 W
+LLMM`
+	mission, journal := testJournal(t, input, &Options{Grid: HexGrid})
+	if mission.String() != "beta -1 1 NE" {
+		t.Fatalf("expected the robot to cross the portal to beta - got %s instead", mission.String())
+	}
+	testReplay(t, mission, journal)
+}
+
+func TestReplay_Types(t *testing.T) {
+	input := `
+5 5
+type scout 2 LRM avoid 500ms
+type drone 1 LM cross 1s
+obstacle 2 2
+0 0 E scout
+MMLM
+2 1 N drone
+MM`
+	mission, journal := testJournal(t, input, &Options{})
+	if mission.String() != "4 2 N scout\n2 3 N drone" {
+		t.Fatalf("expected the scout to move two cells at a time and the drone to cross the obstacle - got %s instead", mission.String())
+	}
+	if !strings.Contains(journal.String(), `"type":"obstacle_placed","obstacle":{"x":2,"y":2}`) {
+		t.Fatalf("expected the journal to record the obstacle:\n%s", journal)
+	}
+	testReplay(t, mission, journal)
+}
+
+func TestReplay_Speed(t *testing.T) {
+	input := `
+plateau alpha 2 2
+plateau beta 2 2
+portal alpha 2 1 beta 0 0 N
+0 1 E
+M`
+	mission, journal := testJournal(t, input, &Options{RobotSpeeds: map[int]int{0: 3}})
+	if mission.String() != "beta 0 1 N" {
+		t.Fatalf("expected the robot to carry on across the portal within a single move - got %s instead", mission.String())
+	}
+	if !strings.Contains(journal.String(), `"speed":3`) {
+		t.Fatalf("expected the journal to record the robot's speed:\n%s", journal)
+	}
+	testReplay(t, mission, journal)
+}
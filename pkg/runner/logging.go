@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// discardLogger is used whenever no logger has been given.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// logger returns the logger given within the options, or a logger that discards every record.
+func (o *Options) logger() *slog.Logger {
+	if o.Logger == nil {
+		return discardLogger
+	}
+	return o.Logger
+}
+
+// ErrorAttr returns an attribute named "error" that groups the message of a given error along
+// with the name of its type and each of its exported fields, for example the ID, X and Y of a
+// RobotOutOfBoundsError. A field holding an error is given as its message.
+func ErrorAttr(err error) slog.Attr {
+	attrs := []slog.Attr{slog.String("message", err.Error())}
+	v := reflect.ValueOf(err)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	attrs = append(attrs, slog.String("type", v.Type().Name()))
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			value := v.Field(i)
+			if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+				if value.IsNil() {
+					continue
+				}
+				if e, ok := value.Interface().(error); ok {
+					attrs = append(attrs, slog.String(field.Name, e.Error()))
+					continue
+				}
+				value = value.Elem()
+			}
+			attrs = append(attrs, slog.Any(field.Name, value.Interface()))
+		}
+	}
+	return slog.Attr{Key: "error", Value: slog.GroupValue(attrs...)}
+}
+
+// logStep logs a single step taken by the manager's robot from a given pose at debug level,
+// along with the way the step went awry, if at all.
+func (m *manager) logStep(move travel.Movement, before Pose, slip Slip) {
+	logger := m.opts.logger()
+	if !logger.Enabled(m.ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.Int("id", m.robot.GetID()),
+		slog.String("command", string(move)),
+		slog.String("from", before.String()),
+		slog.String("to", poseOf(m.robot).String()),
+		slog.Int("seq", m.mission.taken),
+		slog.Duration("time", m.clock),
+	}
+	if slip != NoSlip {
+		attrs = append(attrs, slog.String("slip", string(slip)))
+	}
+	logger.LogAttrs(m.ctx, slog.LevelDebug, "robot stepped", attrs...)
+}
+
+// logMission logs the outcome of a mission once it has been run.
+func logMission(ctx context.Context, logger *slog.Logger, mission *Mission, err error) {
+	attrs := []slog.Attr{slog.Int("robots", len(mission.Tracks)), slog.Int("steps", mission.Steps()), slog.Duration("duration", mission.Duration())}
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "mission failed", append(attrs, ErrorAttr(err))...)
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "mission finished", attrs...)
+}
@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func testLogs(t *testing.T, level slog.Level, input string) []map[string]interface{} {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))
+	Simulate(input, &Options{Logger: logger})
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger_Info(t *testing.T) {
+	records := testLogs(t, slog.LevelInfo, "5 5\n1 2 N\nLMLMLMLMM\n0 0 S\nM")
+	var messages []string
+	for _, r := range records {
+		messages = append(messages, r["msg"].(string))
+	}
+	if strings.Join(messages, ",") != "mission started,robot finished,mission failed" {
+		t.Fatalf("expected the mission's start, robot and failure to be logged - got %v instead", messages)
+	}
+	if records[1]["id"] != 0.0 || records[1]["pose"] != "1 3 N" {
+		t.Fatalf("expected the robot's ID and final pose to be logged - got %v instead", records[1])
+	}
+	e := records[2]["error"].(map[string]interface{})
+	if e["type"] != "RobotOutOfBoundsError" || e["ID"] != 2.0 || e["Y"] != -1.0 {
+		t.Fatalf("expected the error's typed fields to be logged - got %v instead", e)
+	}
+}
+
+func TestLogger_Debug(t *testing.T) {
+	records := testLogs(t, slog.LevelDebug, "5 5\n1 2 N\nLM")
+	var steps int
+	for _, r := range records {
+		if r["msg"] == "robot stepped" {
+			steps++
+		}
+	}
+	if steps != 2 {
+		t.Fatalf("expected every step to be logged at debug level - got %d steps instead", steps)
+	}
+}
+
+func TestErrorAttr(t *testing.T) {
+	attr := ErrorAttr(&ParseRobotMovementError{Movement: "X", ID: 2, Err: errors.New("unknown movement")})
+	fields := make(map[string]string)
+	for _, a := range attr.Value.Group() {
+		fields[a.Key] = a.Value.String()
+	}
+	if fields["type"] != "ParseRobotMovementError" || fields["Movement"] != "X" || fields["ID"] != "2" || fields["Err"] != "unknown movement" {
+		t.Fatalf("expected the error's type and fields - got %v instead", fields)
+	}
+}
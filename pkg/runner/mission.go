@@ -0,0 +1,218 @@
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+	"github.com/juubisnake/mars-rover/internal/pkg/robot"
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// Pose is a snapshot of where a robot is positioned within a surface and the direction
+// it is facing. Plateau is the name of the surface whenever a mission spans more than one.
+type Pose struct {
+	Plateau   string
+	X         int
+	Y         int
+	Direction travel.Direction
+}
+
+// poseOf returns the current pose of a given robot.
+func poseOf(r *robot.Robot) Pose {
+	return Pose{Plateau: r.GetPlateau(), X: r.GetX(), Y: r.GetY(), Direction: r.GetDirection()}
+}
+
+// String is a representation of a given pose in the form of X Y DIRECTION, preceded by the
+// name of its plateau whenever it has one.
+func (p Pose) String() string {
+	if p.Plateau != "" {
+		return fmt.Sprintf("%s %d %d %s", p.Plateau, p.X, p.Y, p.Direction)
+	}
+	return fmt.Sprintf("%d %d %s", p.X, p.Y, p.Direction)
+}
+
+// Step is a single command carried out by a robot and the pose the robot was left in
+// once the command had been carried out.
+// Seq is the order in which the step was taken across every robot within the mission,
+// starting at 1, Time is how long after the start of the mission the step finished, and
+// Climb is the change in elevation the step made, which is negative for a descent, and Slip is
+// the way the command went awry, if at all; see Motion.
+type Step struct {
+	Command travel.Movement
+	Pose    Pose
+	Seq     int
+	Time    time.Duration
+	Climb   int
+	Slip    Slip
+}
+
+// Track is the recorded journey of a single robot across a surface.
+// Type is the name of the robot's type, if it has one. Placed is the number of steps that had been taken within the mission before the robot
+// was placed upon the surface, Started is how long after the start of the mission the robot
+// started moving, and Done marks a robot that carried out all of its commands.
+// Err holds the error that halted the robot, if any; a robot that has moved
+// out-of-bounds will have its out-of-bounds pose as its final step.
+type Track struct {
+	ID      int
+	Type    string
+	Start   Pose
+	Placed  int
+	Started time.Duration
+	Steps   []Step
+	Done    bool
+	Err     error
+}
+
+// End returns the pose the robot was left in after its final recorded step.
+func (t *Track) End() Pose {
+	if len(t.Steps) == 0 {
+		return t.Start
+	}
+	return t.Steps[len(t.Steps)-1].Pose
+}
+
+// Ascent returns the total elevation the robot climbed across its recorded steps.
+func (t *Track) Ascent() int {
+	var ascent int
+	for _, s := range t.Steps {
+		ascent += max(s.Climb, 0)
+	}
+	return ascent
+}
+
+// Descent returns the total elevation the robot descended across its recorded steps.
+func (t *Track) Descent() int {
+	var descent int
+	for _, s := range t.Steps {
+		descent += max(-s.Climb, 0)
+	}
+	return descent
+}
+
+// Duration returns how long the robot spent carrying out its recorded steps.
+func (t *Track) Duration() time.Duration {
+	if len(t.Steps) == 0 {
+		return 0
+	}
+	return t.Steps[len(t.Steps)-1].Time - t.Started
+}
+
+// Mission is the full record of an instruction-set that has been run against a surface.
+// Atlas holds every plateau whenever the mission spans more than one, in which case Surface
+// is the first of them. Elevation holds the heightmap of each surface, keyed in the same way
+// as Options.Elevation, and Grid is the topology of the cells the robots moved across. Types holds
+// each type of rover declared by the mission by name, and Obstacles holds every cell holding an
+// obstacle. Err holds the error that halted the mission, if any.
+type Mission struct {
+	Surface   plateau.Surface
+	Grid      Grid
+	Atlas     *plateau.Atlas
+	Elevation map[string]*plateau.Heightmap
+	Types     map[string]*robot.Type
+	Obstacles map[Obstacle]bool
+	Tracks    []*Track
+	Err       error
+	// taken counts the steps taken by every robot as the mission is run.
+	taken int
+}
+
+// Steps returns the total number of steps taken by all of the robots within the mission.
+func (m *Mission) Steps() int {
+	var steps int
+	for _, t := range m.Tracks {
+		steps += len(t.Steps)
+	}
+	return steps
+}
+
+// Duration returns how long after the start of the mission the final step was taken.
+func (m *Mission) Duration() time.Duration {
+	var duration time.Duration
+	for _, t := range m.Tracks {
+		if len(t.Steps) > 0 && t.Steps[len(t.Steps)-1].Time > duration {
+			duration = t.Steps[len(t.Steps)-1].Time
+		}
+	}
+	return duration
+}
+
+// At returns a copy of the mission as it stood after the given number of steps had been
+// taken. A robot only appears once it has been placed upon the surface, so when robots
+// move sequentially a robot only appears once every robot before it has finished moving;
+// a robot that has been placed but has yet to move is included with no steps.
+// A step less than zero or greater than Steps() will return the mission in full.
+func (m *Mission) At(step int) *Mission {
+	if step < 0 || step > m.Steps() {
+		step = m.Steps()
+	}
+	at := &Mission{Surface: m.Surface, Grid: m.Grid, Atlas: m.Atlas, Elevation: m.Elevation, Types: m.Types, Obstacles: m.Obstacles}
+	for _, t := range m.Tracks {
+		if t.Placed > step {
+			continue
+		}
+		n := sort.Search(len(t.Steps), func(i int) bool { return t.Steps[i].Seq > step })
+		if n == len(t.Steps) {
+			at.Tracks = append(at.Tracks, t)
+			continue
+		}
+		at.Tracks = append(at.Tracks, &Track{ID: t.ID, Type: t.Type, Start: t.Start, Placed: t.Placed, Started: t.Started, Steps: t.Steps[:n]})
+	}
+	if step == m.Steps() {
+		at.Err = m.Err
+	}
+	return at
+}
+
+// surfaces returns every surface within the mission.
+func (m *Mission) surfaces() []plateau.Surface {
+	if m.Atlas == nil {
+		return []plateau.Surface{m.Surface}
+	}
+	var surfaces []plateau.Surface
+	for _, name := range m.Atlas.Names() {
+		surfaces = append(surfaces, m.Atlas.Surface(name))
+	}
+	return surfaces
+}
+
+// surfaceOf returns the surface of the named plateau, or the mission's surface whenever it does
+// not span several plateaus.
+func (m *Mission) surfaceOf(name string) plateau.Surface {
+	if m.Atlas != nil {
+		return m.Atlas.Surface(name)
+	}
+	return m.Surface
+}
+
+// heightOf returns the elevation of a cell within the mission.
+func (m *Mission) heightOf(c cell) int {
+	if h := m.Elevation[c.plateau]; h != nil {
+		return h.Height(c.x, c.y)
+	}
+	return 0
+}
+
+// String outputs the final resting positions of every robot that successfully moved across
+// the surface, delimited by '\n'. Whenever the mission has any elevation, each position is
+// followed by the total elevation the robot climbed and descended, I.E "1 3 N +4 -2", and a robot
+// with a type is followed by the name of its type, I.E "1 3 N scout".
+func (m *Mission) String() string {
+	var output []string
+	for _, t := range m.Tracks {
+		if !t.Done {
+			continue
+		}
+		position := t.End().String()
+		if len(m.Elevation) > 0 {
+			position = fmt.Sprintf("%s +%d -%d", position, t.Ascent(), t.Descent())
+		}
+		if t.Type != "" {
+			position += " " + t.Type
+		}
+		output = append(output, position)
+	}
+	return strings.Join(output, "\n")
+}
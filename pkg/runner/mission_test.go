@@ -0,0 +1,90 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+func TestSimulate_Tracks(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLMLMLMM
+3 3 E
+MMRMMRMRRM`
+	m, err := Simulate(input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tracks) != 2 {
+		t.Fatalf("expected Simulate to record 2 tracks - got %d instead", len(m.Tracks))
+	}
+	first := m.Tracks[0]
+	if first.Start != (Pose{X: 1, Y: 2, Direction: travel.North}) {
+		t.Fatalf("expected first track to start at 1 2 N - got %v instead", first.Start)
+	}
+	if len(first.Steps) != 9 {
+		t.Fatalf("expected first track to have 9 steps - got %d instead", len(first.Steps))
+	}
+	if first.Steps[0].Command != travel.Left || first.Steps[0].Pose != (Pose{X: 1, Y: 2, Direction: travel.West}) {
+		t.Fatalf("expected first step to turn left to 1 2 W - got %s to %v instead", first.Steps[0].Command, first.Steps[0].Pose)
+	}
+	if m.String() != "1 3 N\n5 1 E" {
+		t.Fatalf("expected mission to output the final resting positions - got %s instead", m.String())
+	}
+	if m.Steps() != 19 {
+		t.Fatalf("expected mission to have 19 steps - got %d instead", m.Steps())
+	}
+}
+
+func TestSimulate_FailedTrack(t *testing.T) {
+	input := `
+5 5
+1 1 N
+MMMMMMM`
+	m, err := Simulate(input, nil)
+	var oe *RobotOutOfBoundsError
+	if !errors.As(err, &oe) {
+		t.Fatalf("Simulate() should have produced a RobotOutOfBoundsError - got %T instead", err)
+	}
+	track := m.Tracks[0]
+	if track.Err != err {
+		t.Fatalf("expected failed track to hold the error %v - got %v instead", err, track.Err)
+	}
+	if track.End() != (Pose{X: 1, Y: 6, Direction: travel.North}) {
+		t.Fatalf("expected failed track to end at its out-of-bounds pose - got %v instead", track.End())
+	}
+	if m.String() != "" {
+		t.Fatalf("expected failed tracks to be excluded from the output - got %s instead", m.String())
+	}
+}
+
+func TestMission_At(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LM
+3 3 E
+MM`
+	m, err := Simulate(input, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := m.At(1)
+	if len(at.Tracks) != 1 || len(at.Tracks[0].Steps) != 1 {
+		t.Fatalf("expected mission at step 1 to contain a single track with a single step - got %d tracks", len(at.Tracks))
+	}
+	at = m.At(2)
+	if len(at.Tracks) != 2 || len(at.Tracks[1].Steps) != 0 {
+		t.Fatal("expected mission at step 2 to have placed the second robot without moving it")
+	}
+	at = m.At(3)
+	if at.Tracks[1].End() != (Pose{X: 4, Y: 3, Direction: travel.East}) {
+		t.Fatalf("expected second robot to be at 4 3 E after step 3 - got %v instead", at.Tracks[1].End())
+	}
+	if m.At(-1).String() != m.String() {
+		t.Fatal("expected mission at step -1 to be the full mission")
+	}
+}
@@ -0,0 +1,214 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// Slip is the way a command went awry under a stochastic motion model.
+type Slip string
+
+const (
+	// NoSlip marks a command that was carried out as given.
+	NoSlip Slip = ""
+	// SlipStall marks a move that failed to move the robot at all.
+	SlipStall Slip = "stall"
+	// SlipOvershoot marks a move that carried the robot one cell further than its speed.
+	SlipOvershoot Slip = "overshoot"
+	// SlipDriftLeft and SlipDriftRight mark a move that also carried the robot one cell sideways,
+	// to its left and right respectively, without changing the direction it faces.
+	SlipDriftLeft  Slip = "drift_left"
+	SlipDriftRight Slip = "drift_right"
+	// SlipOverRotate marks a turn that rotated the robot twice as far as it should have.
+	SlipOverRotate Slip = "over_rotate"
+)
+
+// Motion is a stochastic motion model, under which each move may stall, overshoot or drift
+// sideways, and each turn may over-rotate, with the given probabilities. At most one of Stall,
+// Overshoot and Drift happens to any single move, so together they must not exceed one.
+//
+// Every step of every robot draws from its own source of randomness, seeded by Seed, the robot's ID
+// and the number of steps it has taken, so that a mission run twice with the same seed has
+// identical results regardless of how the robots' commands are interleaved. A session restored
+// from a snapshot taken without history draws as though its robots had taken no steps.
+type Motion struct {
+	Seed       uint64
+	Stall      float64
+	Overshoot  float64
+	Drift      float64
+	OverRotate float64
+}
+
+// check returns a MotionError if the model does not hold valid probabilities.
+func (m *Motion) check() error {
+	if m == nil {
+		return nil
+	}
+	for _, p := range []struct {
+		name  string
+		value float64
+	}{{"stall", m.Stall}, {"overshoot", m.Overshoot}, {"drift", m.Drift}, {"over-rotate", m.OverRotate}} {
+		if p.value < 0 || p.value > 1 {
+			return &MotionError{Reason: fmt.Sprintf("the %s probability of %v is not between 0 and 1", p.name, p.value)}
+		}
+	}
+	if m.Stall+m.Overshoot+m.Drift > 1 {
+		return &MotionError{Reason: fmt.Sprintf("the stall, overshoot and drift probabilities total %v, which is more than 1", m.Stall+m.Overshoot+m.Drift)}
+	}
+	return nil
+}
+
+// draw returns the way the given movement goes awry, if at all, when carried out as the given step
+// of the robot with the given ID. Each step draws from its own source of randomness, so that a
+// robot which waits before retrying a command slips in the same way when it does so.
+func (m *Motion) draw(id, step int, move travel.Movement) Slip {
+	r := rand.New(rand.NewPCG(m.Seed, uint64(id)<<32|uint64(step)))
+	p := r.Float64()
+	if move != travel.Move {
+		if p < m.OverRotate {
+			return SlipOverRotate
+		}
+		return NoSlip
+	}
+	switch {
+	case p < m.Stall:
+		return SlipStall
+	case p < m.Stall+m.Overshoot:
+		return SlipOvershoot
+	case p < m.Stall+m.Overshoot+m.Drift:
+		if r.IntN(2) == 0 {
+			return SlipDriftLeft
+		}
+		return SlipDriftRight
+	}
+	return NoSlip
+}
+
+// slip returns the way the given movement goes awry when the manager's robot carries it out as
+// its next step, or NoSlip whenever there is no motion model.
+func (m *manager) slip(move travel.Movement) Slip {
+	if m.opts.Motion == nil || m.track == nil {
+		return NoSlip
+	}
+	return m.opts.Motion.draw(m.robot.GetID(), len(m.track.Steps), move)
+}
+
+// parseSlip parses the way a recorded command went awry.
+func parseSlip(s string) (Slip, error) {
+	switch slip := Slip(s); slip {
+	case NoSlip, SlipStall, SlipOvershoot, SlipDriftLeft, SlipDriftRight, SlipOverRotate:
+		return slip, nil
+	}
+	return NoSlip, fmt.Errorf("'%s' is not a known slip", s)
+}
+
+// Outcomes is the distribution of the results of running a mission many times.
+type Outcomes struct {
+	Runs   int
+	Robots []*RobotOutcomes
+}
+
+// RobotOutcomes is the distribution of a single robot's results across every run of a mission.
+// Poses counts the number of runs in which the robot was left in each pose, for every run in
+// which it was placed upon the surface, and Failures counts the number of runs in which it did
+// not carry out all of its commands, including any run in which it was never placed.
+type RobotOutcomes struct {
+	ID       int
+	Poses    map[Pose]int
+	Failures int
+}
+
+// FailureRate returns the proportion of runs in which the robot did not carry out all of its
+// commands.
+func (o *Outcomes) FailureRate(r *RobotOutcomes) float64 {
+	if o.Runs == 0 {
+		return 0
+	}
+	return float64(r.Failures) / float64(o.Runs)
+}
+
+// String outputs the failure rate of each robot followed by each of its final poses, from the
+// most to the least common, along with the proportion of runs that ended in them, I.E
+//
+//	robot 0: 12.0% failed
+//	  1 3 N 80.0%
+//	  1 4 N 20.0%
+func (o *Outcomes) String() string {
+	var lines []string
+	for _, r := range o.Robots {
+		lines = append(lines, fmt.Sprintf("robot %d: %.1f%% failed", r.ID, 100*o.FailureRate(r)))
+		poses := make([]Pose, 0, len(r.Poses))
+		for p := range r.Poses {
+			poses = append(poses, p)
+		}
+		sort.Slice(poses, func(i, j int) bool {
+			if r.Poses[poses[i]] != r.Poses[poses[j]] {
+				return r.Poses[poses[i]] > r.Poses[poses[j]]
+			}
+			return poses[i].String() < poses[j].String()
+		})
+		for _, p := range poses {
+			lines = append(lines, fmt.Sprintf("  %s %.1f%%", p, 100*float64(r.Poses[p])/float64(o.Runs)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MonteCarlo runs an instruction-set the given number of times under the motion model given by
+// Options.Motion, using the seeds Seed, Seed+1 and so on for each run, and returns the
+// distribution of each robot's final pose along with how often it failed. The Journal, Observer,
+// Recorder and Logger of the options are not used by any run.
+//
+// MonteCarlo returns an error if the instruction-set or options are invalid, without running
+// the mission, or if the context is cancelled, along with the outcomes of every run so far.
+func MonteCarlo(ctx context.Context, input string, runs int, opts *Options) (*Outcomes, error) {
+	if opts == nil || opts.Motion == nil {
+		return nil, &MotionError{Reason: "no motion model was given"}
+	}
+	if runs < 1 {
+		return nil, &MotionError{Reason: fmt.Sprintf("%d runs is not at least 1", runs)}
+	}
+	run := *opts
+	run.Journal, run.Observer, run.Recorder, run.Logger = nil, nil, nil, nil
+	motion := *opts.Motion
+	run.Motion = &motion
+	_, instructions, err := parseInput(input, &run)
+	if err != nil {
+		return nil, err
+	}
+	outcomes := &Outcomes{}
+	robots := make(map[int]*RobotOutcomes)
+	for i := 0; i+1 < len(instructions); i += 2 {
+		robots[i] = &RobotOutcomes{ID: i, Poses: make(map[Pose]int)}
+		outcomes.Robots = append(outcomes.Robots, robots[i])
+	}
+	for i := 0; i < runs; i++ {
+		motion.Seed = opts.Motion.Seed + uint64(i)
+		mission, err := SimulateContext(ctx, input, &run)
+		if mission == nil {
+			return nil, err
+		}
+		var cancelled *CancelledError
+		if errors.As(err, &cancelled) {
+			return outcomes, err
+		}
+		outcomes.Runs++
+		done := make(map[int]bool)
+		for _, t := range mission.Tracks {
+			robots[t.ID].Poses[t.End()]++
+			done[t.ID] = t.Done
+		}
+		for _, r := range outcomes.Robots {
+			if !done[r.ID] {
+				r.Failures++
+			}
+		}
+	}
+	return outcomes, nil
+}
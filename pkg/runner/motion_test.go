@@ -0,0 +1,169 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate_Motion(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLMLMLMM
+3 3 E
+MMRMMRMRRM`
+	opts := &Options{Motion: &Motion{Seed: 42, Stall: 0.2, Overshoot: 0.1, Drift: 0.2, OverRotate: 0.1}}
+	first, firstErr := Simulate(input, opts)
+	second, secondErr := Simulate(input, opts)
+	if !reflect.DeepEqual(first, second) || !reflect.DeepEqual(firstErr, secondErr) {
+		t.Fatalf("expected the same seed to give the same mission - got %s and %s instead", first, second)
+	}
+	var slipped bool
+	for _, track := range first.Tracks {
+		for _, step := range track.Steps {
+			slipped = slipped || step.Slip != NoSlip
+		}
+	}
+	if !slipped {
+		t.Fatal("expected at least one command to slip")
+	}
+}
+
+func TestSimulate_Motion_Simultaneous(t *testing.T) {
+	input := `
+9 9
+4 0 N
+MMMM
+4 9 S
+MMMM`
+	motion := &Motion{Seed: 7, Stall: 0.3, Drift: 0.3}
+	sequential, err := Simulate(input, &Options{Motion: motion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	simultaneous, err := Simulate(input, &Options{Motion: motion, Simultaneous: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sequential.String() != simultaneous.String() {
+		t.Fatalf("expected robots that never meet to slip in the same way in either mode - got %q and %q instead", sequential, simultaneous)
+	}
+}
+
+func TestSimulate_Motion_Slips(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		motion   Motion
+		expected []string
+		slip     Slip
+	}{
+		"stall":       {"5 5\n1 1 N\nMM", Motion{Stall: 1}, []string{"1 1 N"}, SlipStall},
+		"overshoot":   {"5 5\n1 1 N\nM", Motion{Overshoot: 1}, []string{"1 3 N"}, SlipOvershoot},
+		"drift":       {"5 5\n1 1 N\nM", Motion{Drift: 1}, []string{"0 2 N", "2 2 N"}, ""},
+		"over-rotate": {"5 5\n1 1 N\nL", Motion{OverRotate: 1}, []string{"1 1 S"}, SlipOverRotate},
+	}
+	for name, test := range tests {
+		mission, err := Simulate(test.input, &Options{Motion: &test.motion})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var found bool
+		for _, pose := range test.expected {
+			found = found || mission.String() == pose
+		}
+		if !found {
+			t.Fatalf("%s: expected one of %v - got %s instead", name, test.expected, mission)
+		}
+		for _, step := range mission.Tracks[0].Steps {
+			if test.slip != NoSlip && step.Slip != test.slip || step.Slip == NoSlip {
+				t.Fatalf("%s: expected every step to slip with %q - got %q instead", name, test.slip, step.Slip)
+			}
+		}
+	}
+}
+
+func TestSimulate_Motion_Blocked(t *testing.T) {
+	_, err := Simulate("5 5\nobstacle 1 3\n1 1 N\nM", &Options{Motion: &Motion{Overshoot: 1}})
+	var blocked *RobotObstacleError
+	if !errors.As(err, &blocked) || blocked.Y != 3 {
+		t.Fatalf("expected an overshoot into the obstacle to be blocked - got %v instead", err)
+	}
+}
+
+func TestSimulate_Motion_Invalid(t *testing.T) {
+	tests := map[string]Motion{
+		"negative":  {Stall: -0.1},
+		"too large": {OverRotate: 1.5},
+		"total":     {Stall: 0.5, Overshoot: 0.3, Drift: 0.3},
+	}
+	for name, motion := range tests {
+		_, err := Simulate("5 5\n1 1 N\nM", &Options{Motion: &motion})
+		if reflect.TypeOf(err) != reflect.TypeOf(&MotionError{}) {
+			t.Fatalf("%s: expected a MotionError - got %v instead", name, err)
+		}
+	}
+}
+
+func TestReplay_Motion(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLMLMLMM
+3 3 E
+MMRMMRMRRM`
+	mission, journal := testJournal(t, input, &Options{Motion: &Motion{Seed: 3, Stall: 0.2, Overshoot: 0.2, Drift: 0.2, OverRotate: 0.2}})
+	testReplay(t, mission, journal)
+}
+
+func TestMonteCarlo(t *testing.T) {
+	input := `
+5 5
+1 1 N
+M
+3 3 E
+MMMMM`
+	opts := &Options{Motion: &Motion{Seed: 1, Stall: 0.5}}
+	outcomes, err := MonteCarlo(context.Background(), input, 100, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := MonteCarlo(context.Background(), input, 100, opts)
+	if !reflect.DeepEqual(outcomes, again) {
+		t.Fatalf("expected the same seed to give the same outcomes - got %s and %s instead", outcomes, again)
+	}
+	if outcomes.Runs != 100 || len(outcomes.Robots) != 2 {
+		t.Fatalf("expected 100 runs of 2 robots - got %d runs of %d robots instead", outcomes.Runs, len(outcomes.Robots))
+	}
+	first := outcomes.Robots[0]
+	stalled, moved := first.Poses[Pose{X: 1, Y: 1, Direction: "N"}], first.Poses[Pose{X: 1, Y: 2, Direction: "N"}]
+	if stalled+moved != 100 || stalled == 0 || moved == 0 || first.Failures != 0 {
+		t.Fatalf("expected robot 0 to either stall or move in every run - got %s instead", outcomes)
+	}
+	// the second robot only stays within bounds if it stalls at least once.
+	second := outcomes.Robots[1]
+	if second.ID != 2 || second.Failures == 0 || second.Failures == 100 {
+		t.Fatalf("expected robot 2 to fail in some runs - got %s instead", outcomes)
+	}
+	if rate := outcomes.FailureRate(second); rate != float64(second.Failures)/100 {
+		t.Fatalf("expected a failure rate of %v - got %v instead", float64(second.Failures)/100, rate)
+	}
+}
+
+func TestMonteCarlo_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		runs int
+		opts *Options
+	}{
+		"no options": {10, nil},
+		"no motion":  {10, &Options{}},
+		"no runs":    {0, &Options{Motion: &Motion{}}},
+	}
+	for name, test := range tests {
+		_, err := MonteCarlo(context.Background(), "5 5\n1 1 N\nM", test.runs, test.opts)
+		if reflect.TypeOf(err) != reflect.TypeOf(&MotionError{}) {
+			t.Fatalf("%s: expected a MotionError - got %v instead", name, err)
+		}
+	}
+}
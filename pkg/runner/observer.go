@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// Observer is notified as a mission or session is run, allowing its progress to be followed
+// one step at a time. Every method is given the ID of the robot and the robot's current pose.
+//
+// An Observer given to missions that are run concurrently, such as within a batch, must be
+// safe for concurrent use.
+type Observer interface {
+	// RobotDeployed is called once a robot has been placed upon the surface.
+	RobotDeployed(id int, pose Pose)
+	// BeforeCommand is called before a robot carries out a command.
+	BeforeCommand(id int, command string, pose Pose)
+	// AfterCommand is called after a robot has carried out a command, with the pose it was
+	// left in; this may be out of bounds.
+	AfterCommand(id int, command string, pose Pose)
+	// RobotFinished is called once a robot has carried out all of its commands.
+	RobotFinished(id int, pose Pose)
+	// RobotError is called whenever a robot raises an error, such as moving out of bounds. A robot
+	// that raises an error before it has been placed upon the surface, such as when its instruction
+	// cannot be parsed, is given the zero Pose.
+	RobotError(id int, pose Pose, err error)
+}
+
+// NopObserver is an Observer that does nothing, which can be embedded within an Observer that
+// is only interested in some of its methods.
+type NopObserver struct{}
+
+// RobotDeployed does nothing.
+func (NopObserver) RobotDeployed(id int, pose Pose) {}
+
+// BeforeCommand does nothing.
+func (NopObserver) BeforeCommand(id int, command string, pose Pose) {}
+
+// AfterCommand does nothing.
+func (NopObserver) AfterCommand(id int, command string, pose Pose) {}
+
+// RobotFinished does nothing.
+func (NopObserver) RobotFinished(id int, pose Pose) {}
+
+// RobotError does nothing.
+func (NopObserver) RobotError(id int, pose Pose, err error) {}
+
+// Recorder records the outcome of every mission that is run, such as the metrics.Registry.
+//
+// A Recorder given to missions that are run concurrently, such as within a batch, must be safe
+// for concurrent use.
+type Recorder interface {
+	// Record is called once a mission has been run, with the error it returned, if any, and how
+	// long it took to run. The mission is nil if it failed before the surface was constructed.
+	Record(mission *Mission, err error, elapsed time.Duration)
+}
+
+// record passes the outcome of a mission to the recorder.
+func (o *Options) record(mission *Mission, err error, elapsed time.Duration) {
+	if o.Recorder != nil {
+		o.Recorder.Record(mission, err, elapsed)
+	}
+}
+
+// deployed notifies the journal and observer that the manager's robot has been placed upon
+// the surface under a given name, which is empty outside of a session.
+func (m *manager) deployed(name string) {
+	m.recordDeploy(name)
+	if m.opts.Observer != nil {
+		m.opts.Observer.RobotDeployed(m.robot.GetID(), poseOf(m.robot))
+	}
+}
+
+// commanding notifies the observer that the manager's robot is about to carry out a command.
+func (m *manager) commanding(move travel.Movement) {
+	if m.opts.Observer != nil {
+		m.opts.Observer.BeforeCommand(m.robot.GetID(), string(move), poseOf(m.robot))
+	}
+}
+
+// commanded notifies the journal and observer that the manager's robot has carried out a
+// command from a given pose, along with the way the command went awry, if at all.
+func (m *manager) commanded(move travel.Movement, before Pose, slip Slip) {
+	m.recordCommand(move, before, slip)
+	m.logStep(move, before, slip)
+	if m.opts.Observer != nil {
+		m.opts.Observer.AfterCommand(m.robot.GetID(), string(move), poseOf(m.robot))
+	}
+}
+
+// finished marks the manager's robot as having carried out all of its commands, notifying the
+// journal and observer and logging its final pose.
+func (m *manager) finished() {
+	m.track.Done = true
+	m.recordFinish()
+	m.opts.logger().LogAttrs(m.ctx, slog.LevelInfo, "robot finished", slog.Int("id", m.robot.GetID()), slog.String("pose", poseOf(m.robot).String()))
+	if m.opts.Observer != nil {
+		m.opts.Observer.RobotFinished(m.robot.GetID(), poseOf(m.robot))
+	}
+}
+
+// halt marks the manager's robot as having been halted by a given error, notifying the observer.
+func (m *manager) halt(err error) {
+	m.track.Err = err
+	m.raised(err)
+}
+
+// unplaced notifies the observer that the robot with the given ID has raised a given error before
+// it could be placed upon the surface.
+func (o *Options) unplaced(id int, err error) {
+	if o.Observer != nil {
+		o.Observer.RobotError(id, Pose{}, err)
+	}
+}
+
+// raised notifies the observer that the manager's robot has raised a given error.
+func (m *manager) raised(err error) {
+	if m.opts.Observer != nil {
+		m.opts.Observer.RobotError(m.robot.GetID(), poseOf(m.robot), err)
+	}
+}
@@ -0,0 +1,139 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recorder is an Observer that records every notification it is given.
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) RobotDeployed(id int, pose Pose) {
+	r.calls = append(r.calls, fmt.Sprintf("deployed %d %s", id, pose))
+}
+
+func (r *recorder) BeforeCommand(id int, command string, pose Pose) {
+	r.calls = append(r.calls, fmt.Sprintf("before %d %s %s", id, command, pose))
+}
+
+func (r *recorder) AfterCommand(id int, command string, pose Pose) {
+	r.calls = append(r.calls, fmt.Sprintf("after %d %s %s", id, command, pose))
+}
+
+func (r *recorder) RobotFinished(id int, pose Pose) {
+	r.calls = append(r.calls, fmt.Sprintf("finished %d %s", id, pose))
+}
+
+func (r *recorder) RobotError(id int, pose Pose, err error) {
+	r.calls = append(r.calls, fmt.Sprintf("error %d %s", id, pose))
+}
+
+// outcomes is a Recorder that records the error of every mission it is given.
+type outcomes struct {
+	errs []error
+}
+
+func (o *outcomes) Record(mission *Mission, err error, elapsed time.Duration) {
+	o.errs = append(o.errs, err)
+}
+
+func TestRecorder(t *testing.T) {
+	o := &outcomes{}
+	opts := &Options{Recorder: o}
+	Simulate("5 5\n1 2 N\nM", opts)
+	Simulate("5 5\n0 0 S\nM", opts)
+	Simulate("5", opts)
+	if len(o.errs) != 3 || o.errs[0] != nil {
+		t.Fatalf("expected 3 missions to be recorded, the first without an error - got %v instead", o.errs)
+	}
+	if reflect.TypeOf(o.errs[1]) != reflect.TypeOf(&RobotOutOfBoundsError{}) || reflect.TypeOf(o.errs[2]) != reflect.TypeOf(&MissingInputLinesError{}) {
+		t.Fatalf("expected a RobotOutOfBoundsError and a MissingInputLinesError - got %v instead", o.errs[1:])
+	}
+}
+
+func TestObserver_Sequential(t *testing.T) {
+	r := &recorder{}
+	Simulate("2 2\n1 1 N\nLM\n0 0 S\nM", &Options{Observer: r})
+	expected := []string{
+		"deployed 0 1 1 N",
+		"before 0 L 1 1 N",
+		"after 0 L 1 1 W",
+		"before 0 M 1 1 W",
+		"after 0 M 0 1 W",
+		"finished 0 0 1 W",
+		"deployed 2 0 0 S",
+		"before 2 M 0 0 S",
+		"after 2 M 0 -1 S",
+		"error 2 0 -1 S",
+	}
+	if !reflect.DeepEqual(r.calls, expected) {
+		t.Fatalf("expected the observer to be notified of %v - got %v instead", expected, r.calls)
+	}
+}
+
+func TestObserver_Simultaneous(t *testing.T) {
+	r := &recorder{}
+	Simulate("2 2\n0 0 N\nM\n2 2 S\nMM", &Options{Simultaneous: true, Observer: r})
+	expected := []string{
+		"deployed 0 0 0 N",
+		"deployed 2 2 2 S",
+		"before 0 M 0 0 N",
+		"after 0 M 0 1 N",
+		"finished 0 0 1 N",
+		"before 2 M 2 2 S",
+		"after 2 M 2 1 S",
+		"before 2 M 2 1 S",
+		"after 2 M 2 0 S",
+		"finished 2 2 0 S",
+	}
+	if !reflect.DeepEqual(r.calls, expected) {
+		t.Fatalf("expected the observer to be notified of %v - got %v instead", expected, r.calls)
+	}
+}
+
+func TestObserver_PlacementError(t *testing.T) {
+	tests := map[string]*Options{
+		"sequential":   {},
+		"simultaneous": {Simultaneous: true},
+	}
+	for name, opts := range tests {
+		r := &recorder{}
+		opts.Observer = r
+		_, err := Simulate("5 5\n1 1 N\nM\n9 9 N\nM", opts)
+		if !errors.As(err, new(*RobotOutOfBoundsError)) {
+			t.Fatalf("%s: expected robot ID 2 to be placed out of bounds - got %v instead", name, err)
+		}
+		if last := r.calls[len(r.calls)-1]; last != fmt.Sprintf("error 2 %s", Pose{}) {
+			t.Fatalf("%s: expected the observer to be notified of robot ID 2 failing to be placed - got %v instead", name, r.calls)
+		}
+	}
+}
+
+func TestObserver_Session(t *testing.T) {
+	r := &recorder{}
+	s, err := NewSession("5 5", &Options{Observer: r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Deploy("spirit", "1 1 E")
+	s.Deploy("opportunity", "2 1 W")
+	if _, err := s.Command("opportunity", "M"); !errors.As(err, new(*RobotCollisionError)) {
+		t.Fatalf("expected a collision - got %v instead", err)
+	}
+	expected := []string{"deployed 0 1 1 E", "deployed 1 2 1 W", "error 1 2 1 W"}
+	if !reflect.DeepEqual(r.calls, expected) {
+		t.Fatalf("expected the observer to be notified of %v - got %v instead", expected, r.calls)
+	}
+}
+
+func TestNopObserver(t *testing.T) {
+	var o Observer = struct{ NopObserver }{}
+	if _, err := Simulate("5 5\n1 2 N\nLMLMLMLMM", &Options{Observer: o}); err != nil {
+		t.Fatal(err)
+	}
+}
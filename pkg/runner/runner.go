@@ -1,8 +1,13 @@
 package runner
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
 	"github.com/juubisnake/mars-rover/internal/pkg/robot"
@@ -12,18 +17,43 @@ import (
 const (
 	// minimumInputLines is a smallest input set possible for a simulation.
 	minimumInputLines = 3
-	// requiredSurfaceDimensions maps to the possible number of dimensions within a surface
-	// E.G 1 is a 1D surface, 2 is a 2D surface, 3 is a 3D surface and so on.
+	// requiredSurfaceDimensions is the number of dimensions of each coordinate within a surface.
+	// A surface is always 2D; the elevation of each cell is given separately by a heightmap,
+	// see Options.Elevation, rather than by a third dimension.
 	requiredSurfaceDimensions = 2
 	// robotInstructionLength is the number of instructions required to create a robot.
 	robotInstructionLength = 3
+	// portalInstructionLength is the number of instructions required to link a portal,
+	// excluding the portal keyword.
+	portalInstructionLength = 7
+	// plateauKeyword begins an instruction that constructs a named plateau.
+	plateauKeyword = "plateau"
+	// portalKeyword begins an instruction that links a cell upon one plateau to another.
+	portalKeyword = "portal"
+	// cancellationInterval is the number of commands carried out between each check of
+	// whether a run has been cancelled.
+	cancellationInterval = 1024
 )
 
 // manager contains helper functions that create and guides a
 // robot along a given surface.
 type manager struct {
-	surface *plateau.Surface
+	ctx     context.Context
+	surface plateau.Surface
+	// atlas holds every plateau whenever the mission spans more than one.
+	atlas   *plateau.Atlas
 	robot   *robot.Robot
+	mission *Mission
+	track   *Track
+	// durations is how long the robot takes to carry out each command.
+	durations Durations
+	// climb is the steepest change in elevation the robot can make in a single move.
+	climb Climb
+	// speed is the number of cells each move carries the robot; zero is treated as one.
+	speed int
+	// clock is the time at which the robot will have finished its most recent command.
+	clock time.Duration
+	opts  *Options
 }
 
 // Run takes an instruction-set and uses it to generate a surface and
@@ -67,6 +97,43 @@ type manager struct {
 // Note that output is delimited by '\n' and the number of lines is equal to
 // the number of robots constructed.
 //
+// A mission may instead span several named plateaus linked by portals, in which case the
+// lines before the robots describe each plateau and portal, and each robot's line may begin
+// with the plateau it is placed upon, for example:
+//
+//
+// plateau alpha 5 5
+//
+// plateau beta -2 -2 2 2
+//
+// portal alpha 5 3 beta -2 0 E
+//
+// alpha 1 3 E
+//
+// MMMMM
+//
+//
+// A robot placed without a plateau is placed upon the first plateau. A robot that drives onto
+// a portal's cell continues from the portal's entry cell upon the linked plateau, facing the
+// portal's direction, and every resting place is preceded by its plateau, I.E "beta -1 0 E".
+//
+// The robots may also be preceded by lines declaring types of rover and placing obstacles, in
+// which case each robot's line may end with its type, for example:
+//
+//
+// 5 5
+//
+// type scout 2 LRM avoid 500ms
+//
+// obstacle 2 2
+//
+// 0 0 E scout
+//
+// MMLM
+//
+//
+// Each resting place of a robot with a type is followed by its type, I.E "4 2 N scout".
+//
 // If an error occurs, Run will return the resting places of ALL robots that
 // have successfully moved across the surface AND the error, for example:
 //
@@ -84,59 +151,500 @@ type manager struct {
 //
 // Will return a string "1 1 W" and an out-of-bounds error.
 func Run(input string) (string, error) {
+	return RunContext(context.Background(), input)
+}
+
+// RunContext is the same as Run, but stops running the instruction-set once the context
+// has been cancelled. A cancelled run returns the resting places of ALL robots that
+// successfully moved across the surface before it was cancelled AND a CancelledError.
+func RunContext(ctx context.Context, input string) (string, error) {
+	mission, err := SimulateContext(ctx, input, nil)
+	if mission == nil {
+		return "", err
+	}
+	return mission.String(), err
+}
+
+// DefaultDurations is how long each command takes for a robot when no durations are given.
+var DefaultDurations = Durations{Turn: time.Second, Move: time.Second}
+
+// Durations is how long a robot takes to carry out each kind of command.
+type Durations struct {
+	Turn time.Duration
+	Move time.Duration
+}
+
+// of returns how long the given movement takes.
+func (d Durations) of(move travel.Movement) time.Duration {
+	if move == travel.Move {
+		return d.Move
+	}
+	return d.Turn
+}
+
+// Climb is the greatest change in elevation a robot can make in a single move, upward and
+// downward respectively. A zero limit means there is no limit.
+type Climb struct {
+	Up   int
+	Down int
+}
+
+// Options configures how an instruction-set is run.
+type Options struct {
+	// Simultaneous places every robot upon the surface before advancing each robot by one
+	// command per tick, rather than moving each robot in turn; see simulateSimultaneous.
+	Simultaneous bool
+	// Durations is how long each command takes for every robot; defaults to DefaultDurations.
+	Durations *Durations
+	// RobotDurations overrides Durations for the robot with the given ID, including the turning
+	// time of the robot's type.
+	RobotDurations map[int]Durations
+	// TimeLimit halts the mission with a RobotTimeoutError whenever a robot is unable to
+	// carry out a command before the limit has passed; zero means there is no limit.
+	TimeLimit time.Duration
+	// Limits rejects any mission that breaks them with a LimitError; nil means there are no limits.
+	Limits *Limits
+	// Journal records every action taken within the mission; nil means nothing is recorded.
+	Journal *Journal
+	// Observer is notified as each robot is deployed, carries out each command, finishes
+	// and raises an error; nil means nothing is notified.
+	Observer Observer
+	// Recorder records the outcome of every mission that is run, such as for metrics; nil means
+	// nothing is recorded.
+	Recorder Recorder
+	// Elevation gives the height of each cell upon the plateau with the given name, where the
+	// surface of a mission that does not span several plateaus is named by the empty string;
+	// nil means every surface is flat. A heightmap given for any other name is ignored.
+	Elevation map[string]*plateau.Heightmap
+	// Climb halts the mission with a RobotClimbError whenever a robot would make a move that
+	// is steeper than it; nil means a robot can make any move.
+	Climb *Climb
+	// RobotClimbs overrides Climb for the robot with the given ID.
+	RobotClimbs map[int]Climb
+	// Speed is the number of cells each move carries every robot whose type does not give its
+	// own step; zero means a single cell, and a negative speed is a SpeedError. Every cell a robot
+	// passes through must be within bounds and free of obstacles, and whenever robots share the
+	// surface, of other robots.
+	Speed int
+	// RobotSpeeds overrides Speed and the step of the robot's type for the robot with the given ID,
+	// and must be at least 1.
+	RobotSpeeds map[int]int
+	// Motion makes each command liable to go awry, such as a move that stalls or drifts sideways;
+	// nil means every command is carried out exactly as given.
+	Motion *Motion
+	// Grid is the topology of the cells the robots move across; defaults to SquareGrid.
+	Grid Grid
+	// Surface is the surface the robots move across, such as a polygon or cell mask, in which
+	// case the instruction-set holds only the robot instructions; nil means the surface is
+	// described by the first line of the instruction-set.
+	Surface plateau.Surface
+	// Logger logs the start and outcome of the mission, each robot once it has finished and
+	// every error raised, along with every step taken at debug level; nil means nothing is logged.
+	Logger *slog.Logger
+}
+
+// Limits restricts the resources a mission may use, protecting the caller from
+// instruction-sets that are too large to run. A zero limit means there is no limit.
+type Limits struct {
+	// MaxArea is the maximum number of cells within the rectangle bounding each surface.
+	MaxArea int
+	// MaxRobots is the maximum number of robots within a mission.
+	MaxRobots int
+	// MaxCommands is the maximum number of commands given to a single robot.
+	MaxCommands int
+	// MaxSteps is the maximum number of steps taken by every robot within a mission.
+	MaxSteps int
+}
+
+// check checks the surfaces, number of robots and the commands given to each robot against
+// the limits before any robot is placed.
+func (l *Limits) check(surfaces []plateau.Surface, instructions []string) error {
+	if l == nil {
+		return nil
+	}
+	if l.MaxArea > 0 {
+		for _, surface := range surfaces {
+			bounds := surface.Bounds()
+			width := span(bounds.LowerBoundX, bounds.UpperBoundX)
+			height := span(bounds.LowerBoundY, bounds.UpperBoundY)
+			if width == 0 || height == 0 || width > uint64(l.MaxArea)/height {
+				return &LimitError{Limit: AreaLimit, Max: l.MaxArea}
+			}
+		}
+	}
+	robots := len(instructions) / 2
+	if l.MaxRobots > 0 && robots > l.MaxRobots {
+		return &LimitError{Limit: RobotsLimit, Max: l.MaxRobots}
+	}
+	if l.MaxCommands > 0 {
+		for i := 1; i < len(instructions); i += 2 {
+			if len(strings.TrimSpace(instructions[i])) > l.MaxCommands {
+				return &LimitError{Limit: CommandsLimit, Max: l.MaxCommands}
+			}
+		}
+	}
+	return nil
+}
+
+// durationsFor returns how long each command takes for the given robot, where the turning time
+// of the robot's type, if any, overrides that of Durations.
+func (o *Options) durationsFor(r *robot.Robot) Durations {
+	if d, ok := o.RobotDurations[r.GetID()]; ok {
+		return d
+	}
+	d := DefaultDurations
+	if o.Durations != nil {
+		d = *o.Durations
+	}
+	if t := r.GetType(); t != nil && t.Turn > 0 {
+		d.Turn = t.Turn
+	}
+	return d
+}
+
+// climbFor returns the steepest change in elevation the robot with the given ID can make.
+func (o *Options) climbFor(id int) Climb {
+	if c, ok := o.RobotClimbs[id]; ok {
+		return c
+	}
+	if o.Climb != nil {
+		return *o.Climb
+	}
+	return Climb{}
+}
+
+// checkSpeeds returns a SpeedError if Speed is negative or any of RobotSpeeds is less than 1,
+// naming the robot with the lowest ID whenever several are.
+func (o *Options) checkSpeeds() error {
+	if o.Speed < 0 {
+		return &SpeedError{Speed: o.Speed}
+	}
+	ids := make([]int, 0, len(o.RobotSpeeds))
+	for id := range o.RobotSpeeds {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if s := o.RobotSpeeds[id]; s < 1 {
+			return &SpeedError{ID: &id, Speed: s}
+		}
+	}
+	return nil
+}
+
+// speedFor returns the number of cells each move carries the given robot.
+func (o *Options) speedFor(r *robot.Robot) int {
+	if s := o.RobotSpeeds[r.GetID()]; s > 0 {
+		return s
+	}
+	if t := r.GetType(); t != nil && t.Step > 0 {
+		return t.Step
+	}
+	if o.Speed > 0 {
+		return o.Speed
+	}
+	return 1
+}
+
+// Simulate takes an instruction-set in the same format as Run, but rather than returning
+// the final resting positions of the robots it returns a Mission that records every step
+// taken by each robot. A nil Options will run the robots sequentially.
+//
+// If an error occurs after the surface has been constructed, Simulate will return the
+// mission up until the point of failure AND the error; the track of a robot that failed
+// while moving will hold the error that halted it.
+func Simulate(input string, opts *Options) (*Mission, error) {
+	return SimulateContext(context.Background(), input, opts)
+}
+
+// SimulateContext is the same as Simulate, but stops running the instruction-set once the
+// context has been cancelled. The context is checked before each robot is placed, and
+// periodically while robots are moving; a cancelled run returns the mission up until the
+// point of cancellation AND a CancelledError.
+func SimulateContext(ctx context.Context, input string, opts *Options) (*Mission, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	start := time.Now()
+	logger := opts.logger()
+	logger.LogAttrs(ctx, slog.LevelInfo, "mission started", slog.Bool("simultaneous", opts.Simultaneous))
+	mission, instructions, err := parseInput(input, opts)
+	if err == nil {
+		err = opts.Limits.check(mission.surfaces(), instructions)
+	}
+	if err != nil {
+		opts.Journal.recordError(nil, err)
+		logger.LogAttrs(ctx, slog.LevelError, "mission failed", ErrorAttr(err))
+		opts.record(nil, err, time.Since(start))
+		return nil, err
+	}
+	mission.Elevation = opts.Elevation
+	opts.Journal.recordMission(mission)
+	if opts.Simultaneous {
+		err = simulateSimultaneous(ctx, mission, instructions, opts)
+	} else {
+		err = simulateSequential(ctx, mission, instructions, opts)
+	}
+	if err != nil {
+		recordMissionError(opts.Journal, mission, err)
+	}
+	logMission(ctx, logger, mission, err)
+	mission.Err = err
+	opts.record(mission, err, time.Since(start))
+	return mission, err
+}
+
+// span returns the number of cells between a lower and upper bound inclusive, or zero if
+// there are too many cells to count.
+func span(lower, upper int) uint64 {
+	return uint64(upper) - uint64(lower) + 1
+}
+
+// parseInput splits an instruction-set into its lines and constructs the surface described
+// by the first line, or the plateaus and portals described by the lines before the robots,
+// returning a mission upon them and the remaining robot instructions.
+// When Options.Surface is given the instruction-set holds only the robot instructions.
+// In either case the robot instructions may be preceded by lines declaring each type of rover
+// and placing each obstacle; see Mission.declare.
+func parseInput(input string, opts *Options) (*Mission, []string, error) {
+	if err := opts.Grid.check(); err != nil {
+		return nil, nil, err
+	}
+	if err := opts.Motion.check(); err != nil {
+		return nil, nil, err
+	}
+	if err := opts.checkSpeeds(); err != nil {
+		return nil, nil, err
+	}
 	lines := strings.Split(strings.TrimSpace(input), "\n")
-	if len(lines) < minimumInputLines {
-		return "", &MissingInputLinesError{Lines: len(lines)}
+	var mission *Mission
+	var rest []string
+	switch {
+	case opts.Surface != nil:
+		if robots := len(lines) - declarations(lines); robots < minimumInputLines-1 || robots%2 != 0 {
+			return nil, nil, &RobotInputLinesError{Lines: robots}
+		}
+		mission, rest = &Mission{Surface: opts.Surface, Grid: opts.Grid}, lines
+	case isAtlasInstruction(lines[0]):
+		atlas, n, err := buildAtlas(lines, opts.Grid)
+		if err != nil {
+			return nil, nil, err
+		}
+		rest = lines[n:]
+		if robots := len(rest) - declarations(rest); robots < minimumInputLines-1 || robots%2 != 0 {
+			return nil, nil, &RobotInputLinesError{Lines: robots}
+		}
+		mission = &Mission{Surface: atlas.Surface(atlas.Names()[0]), Atlas: atlas, Grid: opts.Grid}
+	default:
+		n := len(lines) - declarations(lines[1:])
+		if n < minimumInputLines {
+			return nil, nil, &MissingInputLinesError{Lines: n}
+		}
+		if n%2 == 0 {
+			return nil, nil, &EvenInputLinesError{Lines: n}
+		}
+		surface, err := buildSurface(lines[0], opts.Grid)
+		if err != nil {
+			return nil, nil, err
+		}
+		mission, rest = &Mission{Surface: surface, Grid: opts.Grid}, lines[1:]
+	}
+	n := declarations(rest)
+	if err := mission.declare(rest[:n]); err != nil {
+		return nil, nil, err
+	}
+	return mission, rest[n:], nil
+}
+
+// isAtlasInstruction checks if an instruction describes a plateau or a portal.
+func isAtlasInstruction(s string) bool {
+	keyword := strings.Split(strings.TrimSpace(s), " ")[0]
+	return keyword == plateauKeyword || keyword == portalKeyword
+}
+
+// buildAtlas constructs a plateau.Atlas from the instructions describing each plateau and portal,
+// E.G "plateau alpha 5 5" and "portal alpha 5 3 beta -2 0 E", returning the atlas along with
+// the number of instructions that described it.
+func buildAtlas(lines []string, grid Grid) (*plateau.Atlas, int, error) {
+	atlas := plateau.NewAtlas()
+	n := 0
+	for ; n < len(lines) && isAtlasInstruction(lines[n]); n++ {
+		config := strings.Split(strings.TrimSpace(lines[n]), " ")
+		if config[0] == portalKeyword {
+			portal, err := buildPortal(lines[n], config[1:], grid)
+			if err != nil {
+				return nil, 0, err
+			}
+			if err := atlas.Link(portal); err != nil {
+				return nil, 0, &SurfaceError{Err: err}
+			}
+			continue
+		}
+		if len(config) < 3 {
+			return nil, 0, &PlateauInstructionError{Instruction: lines[n]}
+		}
+		surface, err := buildSurface(strings.Join(config[2:], " "), grid)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := atlas.Add(config[1], surface); err != nil {
+			return nil, 0, &SurfaceError{Err: err}
+		}
+	}
+	if len(atlas.Names()) == 0 {
+		return nil, 0, &PlateauInstructionError{Instruction: lines[0]}
+	}
+	return atlas, n, nil
+}
+
+// buildPortal constructs a plateau.Portal given the instructions that follow the portal keyword,
+// which hold the plateau and cell the portal lies upon followed by the plateau, cell and
+// direction a robot enters from.
+func buildPortal(s string, config []string, grid Grid) (plateau.Portal, error) {
+	if len(config) != portalInstructionLength {
+		return plateau.Portal{}, &PlateauInstructionError{Instruction: s}
 	}
-	if len(lines)%2 == 0 {
-		return "", &EvenInputLinesError{Lines: len(lines)}
+	var values [4]int
+	for i, coordinate := range []string{"portal x", "portal y", "entry x", "entry y"} {
+		// the coordinates of each cell follow the name of its plateau.
+		bound := config[1+i+i/2]
+		value, err := strconv.Atoi(bound)
+		if err != nil {
+			return plateau.Portal{}, &ParseSurfaceBoundaryError{Coordinate: coordinate, Bounary: bound, Err: err}
+		}
+		values[i] = value
 	}
-	surface, err := buildSurface(lines[0])
+	direction, err := grid.parseDirection(config[6])
 	if err != nil {
-		return "", err
+		return plateau.Portal{}, &PlateauInstructionError{Instruction: s, Err: err}
 	}
-	instructions := lines[1:]
-	m := &manager{surface: surface}
-	var output []string
+	return plateau.Portal{
+		From:      config[0],
+		At:        plateau.Point{X: values[0], Y: values[1]},
+		To:        config[3],
+		Entry:     plateau.Point{X: values[2], Y: values[3]},
+		Direction: direction,
+	}, nil
+}
+
+// simulateSequential places and guides each robot in turn, so the second robot won't start
+// to move until the first one has finished moving.
+func simulateSequential(ctx context.Context, mission *Mission, instructions []string, opts *Options) error {
+	m := &manager{ctx: ctx, opts: opts, surface: mission.Surface, atlas: mission.Atlas, mission: mission}
 	for i := 0; i+1 < len(instructions); i += 2 {
+		if err := ctx.Err(); err != nil {
+			return &CancelledError{Err: err}
+		}
 		robot, err := m.BuildRobot(i, instructions[i])
 		if err != nil {
-			return strings.Join(output, "\n"), err
+			opts.unplaced(i, err)
+			return err
 		}
 		m.robot = robot
-		position, err := m.GuideRobot(instructions[i+1])
-		if err != nil {
-			return strings.Join(output, "\n"), err
+		m.durations = opts.durationsFor(robot)
+		m.climb = opts.climbFor(robot.GetID())
+		m.speed = opts.speedFor(robot)
+		m.track = &Track{ID: robot.GetID(), Type: typeName(robot), Start: poseOf(robot), Placed: mission.taken, Started: m.clock}
+		mission.Tracks = append(mission.Tracks, m.track)
+		m.deployed("")
+		if _, err := m.GuideRobot(instructions[i+1]); err != nil {
+			m.halt(err)
+			return err
 		}
-		output = append(output, position)
+		m.finished()
 	}
-	return strings.Join(output, "\n"), nil
+	return nil
 }
 
-// buildSurface constructs a plateau.Surface instance given a valid instruction.
-func buildSurface(s string) (*plateau.Surface, error) {
+// buildSurface constructs a plateau.Surface instance given a valid instruction, which holds either
+// the upper-right boundary of a rectangular surface, E.G "5 5", its lower-left boundary followed by
+// its upper-right boundary, E.G "-5 -5 5 5", or three or more vertices of a polygon in order around
+// its boundary, E.G "0 0 4 0 4 2 2 2 2 4 0 4". Upon a hex grid the instruction may instead hold
+// the radius of a hexagon centred upon 0,0, E.G "3".
+func buildSurface(s string, grid Grid) (plateau.Surface, error) {
 	bounds := strings.Split(strings.TrimSpace(s), " ")
-	if len(bounds) != requiredSurfaceDimensions {
+	if grid == HexGrid && len(bounds) == 1 {
+		radius, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, &ParseSurfaceBoundaryError{Coordinate: "radius", Bounary: bounds[0], Err: err}
+		}
+		surface, err := plateau.NewHexagon(0, 0, radius)
+		if err != nil {
+			return nil, &SurfaceError{Err: err}
+		}
+		return surface, nil
+	}
+	if len(bounds) < requiredSurfaceDimensions || len(bounds)%requiredSurfaceDimensions != 0 {
 		return nil, &SurfaceDimensionError{Dimensions: len(bounds), Surface: s}
 	}
-	boundX, err := strconv.Atoi(bounds[0])
-	if err != nil {
-		return nil, &ParseSurfaceBoundaryError{Coordinate: "x", Bounary: bounds[0], Err: err}
+	values := make([]int, len(bounds))
+	for i, bound := range bounds {
+		value, err := strconv.Atoi(bound)
+		if err != nil {
+			return nil, &ParseSurfaceBoundaryError{Coordinate: surfaceCoordinate(len(bounds), i), Bounary: bound, Err: err}
+		}
+		values[i] = value
 	}
-	boundY, err := strconv.Atoi(bounds[1])
-	if err != nil {
-		return nil, &ParseSurfaceBoundaryError{Coordinate: "y", Bounary: bounds[1], Err: err}
+	var surface plateau.Surface
+	var err error
+	switch len(values) {
+	case requiredSurfaceDimensions:
+		surface, err = plateau.New(values[0], values[1])
+	case 2 * requiredSurfaceDimensions:
+		surface, err = plateau.NewWithLowerBounds(values[0], values[1], values[2], values[3])
+	default:
+		vertices := make([]plateau.Point, 0, len(values)/requiredSurfaceDimensions)
+		for i := 0; i < len(values); i += requiredSurfaceDimensions {
+			vertices = append(vertices, plateau.Point{X: values[i], Y: values[i+1]})
+		}
+		surface, err = plateau.NewPolygon(vertices)
 	}
-	surface, err := plateau.New(boundX, boundY)
 	if err != nil {
 		return nil, &SurfaceError{Err: err}
 	}
 	return surface, nil
 }
 
-// BuildRobot constructs a robot given a valid instruction.
+// surfaceCoordinate names the coordinate at the given index of a surface instruction holding
+// the given number of values.
+func surfaceCoordinate(values, i int) string {
+	axis := []string{"x", "y"}[i%requiredSurfaceDimensions]
+	switch values {
+	case requiredSurfaceDimensions:
+		return axis
+	case 2 * requiredSurfaceDimensions:
+		if i < requiredSurfaceDimensions {
+			return "lower " + axis
+		}
+		return axis
+	}
+	return fmt.Sprintf("vertex %d %s", i/requiredSurfaceDimensions, axis)
+}
+
+// BuildRobot constructs a robot given a valid instruction. Whenever the mission spans more than
+// one plateau, the instruction may begin with the plateau to place the robot upon, otherwise the
+// robot is placed upon the first. Whenever the mission declares any types of rover, the
+// instruction may end with the robot's type, I.E "1 2 N scout".
 func (m *manager) BuildRobot(id int, s string) (*robot.Robot, error) {
 	config := strings.Split(strings.TrimSpace(s), " ")
+	var kind *robot.Type
+	length := robotInstructionLength
+	if m.atlas != nil {
+		length++
+	}
+	if last := config[len(config)-1]; len(m.mission.Types) > 0 && (len(config) > length || len(config) > robotInstructionLength && m.mission.Types[last] != nil) {
+		if kind = m.mission.Types[last]; kind == nil {
+			return nil, &UnknownTypeError{ID: id, Type: last}
+		}
+		config = config[:len(config)-1]
+	}
+	var name string
+	if m.atlas != nil {
+		name = m.atlas.Names()[0]
+		if len(config) == robotInstructionLength+1 {
+			name, config = config[0], config[1:]
+		}
+	}
 	if len(config) != robotInstructionLength {
 		return nil, &RobotInstructionLengthError{Instructions: s, ID: id}
 	}
@@ -148,29 +656,227 @@ func (m *manager) BuildRobot(id int, s string) (*robot.Robot, error) {
 	if err != nil {
 		return nil, &ParseRobotCoordinateError{ID: id, Coordinate: "y", Position: config[1], Err: err}
 	}
-	direction, err := travel.ParseDirection(config[2])
+	direction, err := m.mission.Grid.parseDirection(config[2])
 	if err != nil {
 		return nil, &ParseRobotDirectionError{Direction: config[2], ID: id, Err: err}
 	}
-	if m.surface.IsOutOfBounds(x, y) {
-		return nil, &RobotOutOfBoundsError{ID: id, X: x, Y: y}
+	surface := m.surface
+	if m.atlas != nil {
+		if surface = m.atlas.Surface(name); surface == nil {
+			return nil, &UnknownPlateauError{ID: id, Plateau: name}
+		}
+	}
+	if surface.IsOutOfBounds(x, y) {
+		return nil, &RobotOutOfBoundsError{ID: id, Plateau: name, X: x, Y: y}
 	}
-	return robot.New(id, x, y, direction), nil
+	if m.mission.Obstacles[Obstacle{Plateau: name, X: x, Y: y}] && (kind == nil || !kind.CrossesObstacles) {
+		return nil, &RobotObstacleError{ID: id, Plateau: name, X: x, Y: y}
+	}
+	r := robot.NewOn(id, name, x, y, direction)
+	r.SetType(kind)
+	return r, nil
 }
 
 // GuideRobot guides a robot around a surface given a valid instruction.
 func (m *manager) GuideRobot(commands string) (string, error) {
 	fmtdCommands := strings.TrimSpace(commands)
 	for i := range fmtdCommands {
-		cmd := string(fmtdCommands[i])
-		move, err := travel.ParseMovement(cmd)
+		if i%cancellationInterval == cancellationInterval-1 {
+			if err := m.ctx.Err(); err != nil {
+				return "", &CancelledError{Err: err}
+			}
+		}
+		move, err := m.parseMovement(string(fmtdCommands[i]))
 		if err != nil {
-			return "", &ParseRobotMovementError{Movement: cmd, Err: err, ID: m.robot.GetID()}
+			return "", err
 		}
-		m.robot.Move(travel.Travel(m.robot.GetDirection(), move))
-		if m.surface.IsOutOfBounds(m.robot.GetX(), m.robot.GetY()) {
-			return "", &RobotOutOfBoundsError{ID: m.robot.GetID(), X: m.robot.GetX(), Y: m.robot.GetY()}
+		if err := m.moveRobot(move); err != nil {
+			return "", err
 		}
 	}
 	return m.robot.String(), nil
 }
+
+// parseMovement parses a single command for the robot.
+func (m *manager) parseMovement(cmd string) (travel.Movement, error) {
+	move, err := travel.ParseMovement(cmd)
+	if err != nil {
+		return move, &ParseRobotMovementError{Movement: cmd, Err: err, ID: m.robot.GetID()}
+	}
+	return move, nil
+}
+
+// moveRobot carries out a single movement, recording it as a step within the robot's track.
+// A move that carries the robot across several cells is only carried out if it is able to move
+// into every one of them, and a robot that passes out of bounds is lost within the first cell
+// beyond the surface.
+func (m *manager) moveRobot(move travel.Movement) error {
+	if l := m.opts.Limits; l != nil && l.MaxSteps > 0 && m.mission.taken >= l.MaxSteps {
+		return &LimitError{Limit: StepsLimit, Max: l.MaxSteps}
+	}
+	if err := m.checkCommand(move); err != nil {
+		return err
+	}
+	slip := m.slip(move)
+	path, direction := m.path(move, slip)
+	climb, err := m.checkPath(path)
+	if err != nil {
+		return err
+	}
+	if err := m.tick(move); err != nil {
+		return err
+	}
+	m.commanding(move)
+	before := poseOf(m.robot)
+	m.follow(path, direction)
+	if m.track != nil {
+		m.mission.taken++
+		m.track.Steps = append(m.track.Steps, Step{Command: move, Pose: poseOf(m.robot), Seq: m.mission.taken, Time: m.clock, Climb: climb, Slip: slip})
+	}
+	m.commanded(move, before, slip)
+	if m.currentSurface().IsOutOfBounds(m.robot.GetX(), m.robot.GetY()) {
+		return &RobotOutOfBoundsError{ID: m.robot.GetID(), Plateau: m.robot.GetPlateau(), X: m.robot.GetX(), Y: m.robot.GetY()}
+	}
+	return nil
+}
+
+// currentSurface returns the surface the manager's robot is upon.
+func (m *manager) currentSurface() plateau.Surface {
+	return m.surfaceOf(m.robot.GetPlateau())
+}
+
+// surfaceOf returns the surface of the named plateau, or the mission's surface whenever it
+// does not span several plateaus.
+func (m *manager) surfaceOf(name string) plateau.Surface {
+	if m.atlas != nil {
+		return m.atlas.Surface(name)
+	}
+	return m.surface
+}
+
+// checkPath checks each cell along a path in turn, returning the change in elevation between the
+// robot's cell and the final cell of the path, or an error naming the first cell the robot is
+// unable to move into.
+func (m *manager) checkPath(path []cell) (int, error) {
+	start := cellOf(m.robot)
+	from := start
+	for _, to := range path {
+		if err := m.checkClimb(to, m.mission.heightOf(to)-m.mission.heightOf(from)); err != nil {
+			return 0, err
+		}
+		if err := m.checkObstacle(to); err != nil {
+			return 0, err
+		}
+		from = to
+	}
+	return m.mission.heightOf(from) - m.mission.heightOf(start), nil
+}
+
+// checkClimb returns a RobotClimbError whenever moving into the given cell would change the
+// robot's elevation by more than it is able to climb or descend. Moving out of bounds is never
+// too steep, since the robot is lost regardless.
+func (m *manager) checkClimb(to cell, climb int) error {
+	if m.surfaceOf(to.plateau).IsOutOfBounds(to.x, to.y) {
+		return nil
+	}
+	max := m.climb.Up
+	if climb < 0 {
+		max = m.climb.Down
+	}
+	if max > 0 && (climb > max || -climb > max) {
+		return &RobotClimbError{ID: m.robot.GetID(), Plateau: to.plateau, X: to.x, Y: to.y, Climb: climb, Max: max}
+	}
+	return nil
+}
+
+// path returns each cell the robot passes through as it carries out the given movement, ending
+// with the cell it will occupy, along with the direction it will then face. A move carries the
+// robot as many cells as its speed, following any portal that it drives onto along the way, and
+// ends early within the first cell that is out of bounds. A turn passes through no cells.
+//
+// The path is altered by the way the movement goes awry, if at all: a stalled move passes through
+// no cells, an overshooting move passes through one more cell than its speed, a drifting move
+// passes into the cell beside its final cell, and an over-rotating turn turns twice.
+func (m *manager) path(move travel.Movement, slip Slip) ([]cell, travel.Direction) {
+	_, _, direction := m.mission.Grid.travel(m.robot.GetDirection(), move)
+	if move != travel.Move {
+		if slip == SlipOverRotate {
+			_, _, direction = m.mission.Grid.travel(direction, move)
+		}
+		return nil, direction
+	}
+	steps := max(m.speed, 1)
+	switch slip {
+	case SlipStall:
+		return nil, direction
+	case SlipOvershoot:
+		steps++
+	}
+	var path []cell
+	at := cellOf(m.robot)
+	for i := 0; i < steps; i++ {
+		at, direction, _ = m.advance(at, direction)
+		path = append(path, at)
+		if m.surfaceOf(at.plateau).IsOutOfBounds(at.x, at.y) {
+			return path, direction
+		}
+	}
+	if slip == SlipDriftLeft || slip == SlipDriftRight {
+		turn := travel.Left
+		if slip == SlipDriftRight {
+			turn = travel.Right
+		}
+		_, _, side := m.mission.Grid.travel(direction, turn)
+		drift, facing, portal := m.advance(at, side)
+		if portal {
+			direction = facing
+		}
+		path = append(path, drift)
+	}
+	return path, direction
+}
+
+// advance returns the cell next to the given cell in the given direction, following any portal
+// within it, along with the direction faced once within it and whether a portal was followed.
+func (m *manager) advance(from cell, direction travel.Direction) (cell, travel.Direction, bool) {
+	x, y, _ := m.mission.Grid.travel(direction, travel.Move)
+	to := cell{from.plateau, from.x + x, from.y + y}
+	if m.atlas != nil {
+		if p, ok := m.atlas.Portal(to.plateau, to.x, to.y); ok {
+			return cell{p.To, p.Entry.X, p.Entry.Y}, p.Direction, true
+		}
+	}
+	return to, direction, false
+}
+
+// follow moves the robot along the given path, leaving it within the final cell of the path facing
+// the given direction.
+func (m *manager) follow(path []cell, direction travel.Direction) {
+	to := cellOf(m.robot)
+	if len(path) > 0 {
+		to = path[len(path)-1]
+	}
+	m.robot.Transfer(to.plateau, to.x, to.y, direction)
+}
+
+// collision returns the first cell along a path that is occupied by a robot other than the one
+// within the from cell, along with the ID of the robot occupying it.
+func collision(occupied map[cell]int, from cell, path []cell) (cell, int, bool) {
+	for _, c := range path {
+		if other, ok := occupied[c]; ok && c != from {
+			return c, other, true
+		}
+	}
+	return cell{}, 0, false
+}
+
+// tick advances the robot's clock by the time it takes to carry out the given movement.
+// It returns a RobotTimeoutError if the movement would finish after the time limit.
+func (m *manager) tick(move travel.Movement) error {
+	finish := m.clock + m.durations.of(move)
+	if m.opts.TimeLimit > 0 && finish > m.opts.TimeLimit {
+		return &RobotTimeoutError{ID: m.robot.GetID(), Movement: string(move), Time: m.clock, Limit: m.opts.TimeLimit}
+	}
+	m.clock = finish
+	return nil
+}
@@ -1,9 +1,13 @@
 package runner
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
 	"github.com/juubisnake/mars-rover/internal/pkg/travel"
@@ -202,6 +206,311 @@ LMLMRM
 	}
 }
 
+func TestRun_LowerBounds(t *testing.T) {
+	input := `
+-5 -5 5 5
+-5 -5 N
+MMRMM
+0 0 S
+MMMMM`
+	testValidRun(t, input, "-3 -3 E\n0 -5 S")
+	_, err := Run("-5 -5 5 5\n-5 -5 W\nM")
+	var oob *RobotOutOfBoundsError
+	if !errors.As(err, &oob) || oob.X != -6 {
+		t.Fatalf("Run() should have produced a RobotOutOfBoundsError beyond the lower bound - got %v instead", err)
+	}
+}
+
+func TestRun_buildSurface_invalidLowerBounds(t *testing.T) {
+	_, err := Run("1 1 0 5\n1 1 N\nM")
+	var ne *plateau.UpperBoundsError
+	if !errors.As(err, &ne) {
+		t.Fatalf("Run() should have produced a wrapped UpperBoundsError - got %v instead", err)
+	}
+	if ne.Coordinate != "x" || ne.Value != 0 || ne.LowerBound != 1 {
+		t.Fatalf("UpperBoundsError should have failed on the x coordinate against the lower bound of 1 - got %v instead", ne)
+	}
+	_, err = Run("A 0 5 5\n1 1 N\nM")
+	var pe *ParseSurfaceBoundaryError
+	if !errors.As(err, &pe) || pe.Coordinate != "lower x" {
+		t.Fatalf("Run() should have produced a ParseSurfaceBoundaryError for the lower x coordinate - got %v instead", err)
+	}
+}
+
+func TestRun_Polygon(t *testing.T) {
+	// an L-shaped surface, missing its upper-right quarter.
+	input := `
+0 0 4 0 4 2 2 2 2 4 0 4
+0 0 N
+MMMMRMM
+4 0 N
+MMM`
+	mission, err := Simulate(input, nil)
+	var oob *RobotOutOfBoundsError
+	if !errors.As(err, &oob) || oob.X != 4 || oob.Y != 3 {
+		t.Fatalf("Simulate() should have produced a RobotOutOfBoundsError within the missing quarter - got %v instead", err)
+	}
+	if mission.String() != "2 4 E" {
+		t.Fatalf("expected the first robot to finish at 2 4 E - got %s instead", mission.String())
+	}
+	_, err = Run("0 0 4 0 A 4\n0 0 N\nM")
+	var pe *ParseSurfaceBoundaryError
+	if !errors.As(err, &pe) || pe.Coordinate != "vertex 2 x" {
+		t.Fatalf("Run() should have produced a ParseSurfaceBoundaryError for the third vertex - got %v instead", err)
+	}
+}
+
+func TestSimulate_Surface(t *testing.T) {
+	mask, err := plateau.NewMask(0, 0, []string{
+		"...",
+		".#.",
+		"...",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mission, err := Simulate("0 0 E\nMMLMMLMM\n0 1 E\nM", &Options{Surface: mask})
+	var oob *RobotOutOfBoundsError
+	if !errors.As(err, &oob) || oob.X != 1 || oob.Y != 1 {
+		t.Fatalf("Simulate() should have produced a RobotOutOfBoundsError upon the masked cell - got %v instead", err)
+	}
+	if mission.String() != "0 2 W" {
+		t.Fatalf("expected the first robot to travel around the masked cell to 0 2 W - got %s instead", mission.String())
+	}
+	_, err = Simulate("5 5\n0 0 E\nM", &Options{Surface: mask})
+	var re *RobotInputLinesError
+	if !errors.As(err, &re) || re.Lines != 3 {
+		t.Fatalf("Simulate() should have produced a RobotInputLinesError when a surface line is given - got %v instead", err)
+	}
+}
+
+func TestRun_Plateaus(t *testing.T) {
+	input := `
+plateau alpha 3 3
+plateau beta -2 -2 2 2
+portal alpha 3 1 beta -2 0 E
+1 1 E
+MMMM
+beta 0 2 N
+M`
+	mission, err := Simulate(input, nil)
+	var oob *RobotOutOfBoundsError
+	if !errors.As(err, &oob) || oob.Plateau != "beta" || oob.X != 0 || oob.Y != 3 {
+		t.Fatalf("Simulate() should have produced a RobotOutOfBoundsError upon beta - got %v instead", err)
+	}
+	if expected := "robot ID 2 has moved out of bounds - Plateau: beta X: 0 Y: 3"; err.Error() != expected {
+		t.Fatalf("expected error %s - got %s instead", expected, err)
+	}
+	if mission.String() != "beta 0 0 E" {
+		t.Fatalf("expected the first robot to cross the portal to beta 0 0 E - got %s instead", mission.String())
+	}
+	if step := mission.Tracks[0].Steps[1]; step.Pose.String() != "beta -2 0 E" {
+		t.Fatalf("expected the robot to enter beta upon the step onto the portal - got %s instead", step.Pose)
+	}
+}
+
+func TestRun_Plateaus_Invalid(t *testing.T) {
+	for input, expected := range map[string]interface{}{
+		"plateau alpha 3 3\ngamma 1 1 N\nM":                                 &UnknownPlateauError{},
+		"plateau alpha\n1 1 N\nM":                                           &PlateauInstructionError{},
+		"plateau alpha 3 3\nportal alpha 3 1 alpha 0 0\n1 1 N\nM":           &PlateauInstructionError{},
+		"plateau alpha 3 3\nportal alpha 3 1 alpha 0 0 X\n1 1 N\nM":         &PlateauInstructionError{},
+		"plateau alpha 3 3\nportal alpha 3 A alpha 0 0 N\n1 1 N\nM":         &ParseSurfaceBoundaryError{},
+		"plateau alpha 3 3\nportal alpha 3 1 beta 0 0 N\n1 1 N\nM":          &SurfaceError{},
+		"plateau alpha 3 3\nplateau alpha 2 2\n1 1 N\nM":                    &SurfaceError{},
+		"plateau alpha 3 3\n1 1 N":                                          &RobotInputLinesError{},
+		"plateau alpha 3 3\nplateau beta 1 1\nbeta 1 2 N\nM":                &RobotOutOfBoundsError{},
+		"plateau alpha 3 3\nportal alpha 3 1 alpha 0 0 N\nalpha 1 1 N 1\nM": &RobotInstructionLengthError{},
+	} {
+		_, err := Run(input)
+		if reflect.TypeOf(err) != reflect.TypeOf(expected) {
+			t.Fatalf("expected Run(%q) to produce %T - got %v instead", input, expected, err)
+		}
+	}
+}
+
+func TestSimulate_Elevation(t *testing.T) {
+	heightmap, err := plateau.NewHeightmap(0, 0, [][]int{
+		{0, 0, 0},
+		{0, 1, 4},
+		{0, 3, 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `
+2 2
+0 0 E
+MMLM
+0 1 E
+MM`
+	opts := &Options{
+		Elevation:   map[string]*plateau.Heightmap{"": heightmap},
+		Climb:       &Climb{Up: 3, Down: 2},
+		RobotClimbs: map[int]Climb{2: {Up: 2}},
+	}
+	mission, err := Simulate(input, opts)
+	var ce *RobotClimbError
+	if !errors.As(err, &ce) || ce.ID != 2 || ce.X != 2 || ce.Y != 1 || ce.Climb != 3 || ce.Max != 2 {
+		t.Fatalf("Simulate() should have produced a RobotClimbError for the second robot - got %v instead", err)
+	}
+	if expected := "2 1 N +6 -2"; mission.String() != expected {
+		t.Fatalf("expected the first robot's climb and descent to be output as %s - got %s instead", expected, mission.String())
+	}
+	if end := mission.Tracks[1].End(); end.X != 1 || end.Y != 1 {
+		t.Fatalf("expected the second robot not to have made the move that was too steep - got %s instead", end)
+	}
+	opts.Climb = &Climb{Down: 1}
+	_, err = Simulate(input, opts)
+	if !errors.As(err, &ce) || ce.ID != 0 || ce.Climb != -2 || ce.Error() != "robot ID 0 is unable to descend 2 to reach X: 2 Y: 0 - the limit is 1" {
+		t.Fatalf("Simulate() should have produced a RobotClimbError for the first robot's descent - got %v instead", err)
+	}
+}
+
+func TestSimulate_Hex(t *testing.T) {
+	input := `
+2
+0 0 E
+MLMRRRM
+-2 2 SE
+MMMLM
+1 1 W
+RRM`
+	mission, err := Simulate(input, &Options{Grid: HexGrid})
+	var oob *RobotOutOfBoundsError
+	if !errors.As(err, &oob) || oob.ID != 4 || oob.X != 1 || oob.Y != 2 {
+		t.Fatalf("Simulate() should have produced a RobotOutOfBoundsError - got %v instead", err)
+	}
+	if expected := "1 0 SW\n2 -1 E"; mission.String() != expected {
+		t.Fatalf("expected %s - got %s instead", expected, mission.String())
+	}
+	for input, expected := range map[string]interface{}{
+		"A\n0 0 E\nM":     &ParseSurfaceBoundaryError{},
+		"-1\n0 0 E\nM":    &SurfaceError{},
+		"2\n3 0 E\nM":     &RobotOutOfBoundsError{},
+		"2\n0 0 N\nM":     &ParseRobotDirectionError{},
+		"2 2\n0 0 NE\nM":  nil,
+		"1 1 3\n0 0 E\nM": &SurfaceDimensionError{},
+	} {
+		_, err := Simulate(input, &Options{Grid: HexGrid})
+		if reflect.TypeOf(err) != reflect.TypeOf(expected) {
+			t.Fatalf("expected Simulate(%q) to produce %T - got %v instead", input, expected, err)
+		}
+	}
+	if _, err := Simulate("2 2\n0 0 N\nM", &Options{Grid: "triangle"}); err == nil || err.Error() != "'triangle' is not a known grid - expected 'square' or 'hex'" {
+		t.Fatalf("Simulate() should have produced a GridError - got %v instead", err)
+	}
+}
+
+func TestSimulate_Types(t *testing.T) {
+	input := `
+5 5
+type scout 2 LRM avoid 500ms
+type drone 1 LRM cross 1s
+type heavy 1 LM avoid 3s
+obstacle 2 2
+obstacle 0 3
+0 0 E scout
+MMLM
+2 1 N drone
+MM
+1 1 N heavy
+LM
+1 3 W
+M`
+	mission, err := Simulate(input, nil)
+	var oe *RobotObstacleError
+	if !errors.As(err, &oe) || oe.ID != 6 || oe.X != 0 || oe.Y != 3 {
+		t.Fatalf("Simulate() should have produced a RobotObstacleError - got %v instead", err)
+	}
+	if expected := "robot ID 6 is blocked by an obstacle - X: 0 Y: 3"; err.Error() != expected {
+		t.Fatalf("expected error %s - got %s instead", expected, err)
+	}
+	if expected := "4 2 N scout\n2 3 N drone\n0 1 W heavy"; mission.String() != expected {
+		t.Fatalf("expected %s - got %s instead", expected, mission.String())
+	}
+	if d := mission.Tracks[2].Duration(); d != 4*time.Second {
+		t.Fatalf("expected the heavy rover to take 4s to turn and move - got %v instead", d)
+	}
+	if end := mission.Tracks[3].End(); end.X != 1 || end.Y != 3 || mission.Tracks[3].Type != "" {
+		t.Fatalf("expected the rover without a type not to have moved into the obstacle - got %s instead", end)
+	}
+}
+
+func TestRun_Types_Invalid(t *testing.T) {
+	for input, expected := range map[string]interface{}{
+		"5 5\ntype heavy 1 LM avoid 3s\n1 1 N heavy\nR":                                  &RobotCommandError{},
+		"5 5\ntype heavy 1 LM avoid 3s\n1 1 N hauler\nM":                                 &UnknownTypeError{},
+		"5 5\ntype heavy 0 LM avoid 3s\n1 1 N heavy\nM":                                  &DeclarationError{},
+		"5 5\ntype heavy 1 LX avoid 3s\n1 1 N heavy\nM":                                  &DeclarationError{},
+		"5 5\ntype heavy 1 LM over 3s\n1 1 N heavy\nM":                                   &DeclarationError{},
+		"5 5\ntype heavy 1 LM avoid soon\n1 1 N heavy\nM":                                &DeclarationError{},
+		"5 5\ntype heavy 1 LM avoid\n1 1 N heavy\nM":                                     &DeclarationError{},
+		"5 5\ntype heavy 1 LM avoid 3s\ntype heavy 1 M avoid 1s\n1 1 N heavy\nM":         &DeclarationError{},
+		"5 5\nobstacle 6 6\n1 1 N\nM":                                                    &DeclarationError{},
+		"5 5\nobstacle 1 1\n1 1 N\nM":                                                    &RobotObstacleError{},
+		"5 5\nobstacle 1 1\n1 1 N":                                                       &MissingInputLinesError{},
+		"plateau alpha 3 3\nobstacle beta 1 1\n1 1 N\nM":                                 &DeclarationError{},
+		"plateau alpha 3 3\nplateau beta 3 3\nobstacle beta 1 1\nbeta 1 0 N\nM":          &RobotObstacleError{},
+		"plateau alpha 3 3\ntype drone 1 M cross 1s\nobstacle 1 1\nalpha 1 1 N drone\nM": nil,
+	} {
+		_, err := Run(input)
+		if reflect.TypeOf(err) != reflect.TypeOf(expected) {
+			t.Fatalf("expected Run(%q) to produce %T - got %v instead", input, expected, err)
+		}
+	}
+}
+
+func TestSimulate_Speed(t *testing.T) {
+	input := `
+5 5
+type scout 2 LRM avoid 1s
+0 0 N
+MRM
+0 4 E scout
+M
+4 2 N
+M`
+	mission, err := Simulate(input, &Options{Speed: 2, RobotSpeeds: map[int]int{2: 3, 4: 5}})
+	var oob *RobotOutOfBoundsError
+	if !errors.As(err, &oob) || oob.ID != 4 || oob.X != 4 || oob.Y != 6 {
+		t.Fatalf("Simulate() should have produced a RobotOutOfBoundsError within the first cell beyond the surface - got %v instead", err)
+	}
+	if expected := "2 2 E\n3 4 E scout"; mission.String() != expected {
+		t.Fatalf("expected %s - got %s instead", expected, mission.String())
+	}
+	_, err = Simulate("5 5\nobstacle 0 2\nobstacle 0 3\n0 0 N\nMM", &Options{Speed: 3})
+	var oe *RobotObstacleError
+	if !errors.As(err, &oe) || oe.X != 0 || oe.Y != 2 {
+		t.Fatalf("Simulate() should have produced a RobotObstacleError naming the first obstacle passed through - got %v instead", err)
+	}
+}
+
+func TestSimulate_Speed_Invalid(t *testing.T) {
+	tests := map[string]*Options{
+		"negative":   {Speed: -1},
+		"robot":      {RobotSpeeds: map[int]int{0: -2}},
+		"robot zero": {Speed: 2, RobotSpeeds: map[int]int{0: 0}},
+	}
+	for name, opts := range tests {
+		_, err := Simulate("5 5\n1 1 N\nM", opts)
+		if reflect.TypeOf(err) != reflect.TypeOf(&SpeedError{}) {
+			t.Fatalf("%s: expected a SpeedError - got %v instead", name, err)
+		}
+		if _, err := NewSession("5 5", opts); reflect.TypeOf(err) != reflect.TypeOf(&SpeedError{}) {
+			t.Fatalf("%s: expected NewSession to return a SpeedError - got %v instead", name, err)
+		}
+	}
+	opts := &Options{RobotSpeeds: map[int]int{6: 0, 4: -1, 2: 2, 8: -3}}
+	for i := 0; i < 10; i++ {
+		_, err := Simulate("5 5\n1 1 N\nM", opts)
+		var speed *SpeedError
+		if !errors.As(err, &speed) || speed.ID == nil || *speed.ID != 4 {
+			t.Fatalf("expected the lowest invalid robot ID 4 to be named - got %v instead", err)
+		}
+	}
+}
+
 func TestRun_BuildRobot_InvalidInstructionLength(t *testing.T) {
 	input := `
 5 5
@@ -353,3 +662,161 @@ LMLMRM
 		t.Fatalf("robot should have out-of-bounded on y coordinate 6 - got %d instead", pe.Y)
 	}
 }
+
+func TestSimulate_Durations(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLM
+3 3 E
+MMR`
+	opts := &Options{
+		Durations:      &Durations{Turn: time.Second, Move: 3 * time.Second},
+		RobotDurations: map[int]Durations{2: {Turn: 5 * time.Second, Move: time.Second}},
+	}
+	m, err := Simulate(input, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := m.Tracks[0], m.Tracks[1]
+	times := []time.Duration{time.Second, 4 * time.Second, 5 * time.Second, 8 * time.Second}
+	for i, s := range first.Steps {
+		if s.Time != times[i] {
+			t.Fatalf("expected step %d of robot ID 0 to finish at %v - got %v instead", i, times[i], s.Time)
+		}
+	}
+	if first.Duration() != 8*time.Second {
+		t.Fatalf("expected robot ID 0 to take 8s - got %v instead", first.Duration())
+	}
+	if second.Started != 8*time.Second || second.Duration() != 7*time.Second {
+		t.Fatalf("expected robot ID 2 to start at 8s and take 7s - started at %v and took %v instead", second.Started, second.Duration())
+	}
+	if m.Duration() != 15*time.Second {
+		t.Fatalf("expected mission to take 15s - got %v instead", m.Duration())
+	}
+}
+
+func TestSimulate_TimeLimit(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLM
+3 3 E
+MMR`
+	m, err := Simulate(input, &Options{TimeLimit: 5 * time.Second})
+	var te *RobotTimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("Simulate() should have produced a RobotTimeoutError - got %T instead", err)
+	}
+	if te.ID != 2 || te.Movement != "M" || te.Time != 5*time.Second {
+		t.Fatalf("expected robot ID 2 to time out on its second move at 5s - got %v instead", te)
+	}
+	if m.String() != "0 1 S" || len(m.Tracks[1].Steps) != 1 {
+		t.Fatalf("expected robot ID 2 to halt after a single step - got %s instead", m.String())
+	}
+}
+
+func TestSimulate_Simultaneous_Durations(t *testing.T) {
+	input := `
+3 1
+0 1 N
+RM
+2 1 W
+MLM`
+	opts := &Options{
+		Simultaneous:   true,
+		RobotDurations: map[int]Durations{0: {Turn: 3 * time.Second, Move: time.Second}},
+	}
+	m, err := Simulate(input, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.String() != "1 1 E\n1 0 S" {
+		t.Fatalf("expected robot ID 2 to pass robot ID 0 while it turns - got %s instead", m.String())
+	}
+	if s := m.Tracks[1].Steps[0]; s.Seq != 2 || s.Time != time.Second {
+		t.Fatalf("expected robot ID 2 to take the second step at 1s - took step %d at %v instead", s.Seq, s.Time)
+	}
+	if s := m.Tracks[0].Steps[1]; s.Seq != 5 || s.Time != 4*time.Second {
+		t.Fatalf("expected robot ID 0 to take the final step at 4s - took step %d at %v instead", s.Seq, s.Time)
+	}
+}
+
+// countdownContext is a context that is cancelled once Err has been called a given number of times.
+type countdownContext struct {
+	context.Context
+	remaining int
+}
+
+func (c *countdownContext) Err() error {
+	if c.remaining == 0 {
+		return context.Canceled
+	}
+	c.remaining--
+	return nil
+}
+
+func TestRunContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	output, err := RunContext(ctx, "5 5\n1 2 N\nLMLMLMLMM")
+	var ce *CancelledError
+	if !errors.As(err, &ce) {
+		t.Fatalf("RunContext() should have produced a CancelledError - got %T instead", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("CancelledError should have wrapped context.Canceled")
+	}
+	if output != "" {
+		t.Fatalf("expected no robot to have moved - got %s instead", output)
+	}
+}
+
+func TestSimulateContext_CancelledWhileGuiding(t *testing.T) {
+	input := "5 5\n1 2 N\nLMLMLMLMM\n3 3 E\n" + strings.Repeat("L", 10*cancellationInterval)
+	for _, simultaneous := range []bool{false, true} {
+		ctx := &countdownContext{Context: context.Background(), remaining: 2}
+		m, err := SimulateContext(ctx, input, &Options{Simultaneous: simultaneous})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("SimulateContext() should have been cancelled - got %v instead", err)
+		}
+		if m.String() != "1 3 N" {
+			t.Fatalf("expected the partial results of robot ID 0 - got %s instead", m.String())
+		}
+		if steps := len(m.Tracks[1].Steps); steps >= 10*cancellationInterval {
+			t.Fatalf("expected robot ID 2 to have been halted before finishing - took %d steps", steps)
+		}
+	}
+}
+
+func testLimit(t *testing.T, input string, limits *Limits, expected Limit) *Mission {
+	m, err := Simulate(input, &Options{Limits: limits})
+	var le *LimitError
+	if !errors.As(err, &le) {
+		t.Fatalf("Simulate() should have produced a LimitError - got %T instead", err)
+	}
+	if le.Limit != expected {
+		t.Fatalf("expected the %s limit to have been exceeded - got %s instead", expected, le.Limit)
+	}
+	return m
+}
+
+func TestSimulate_Limits(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLMLMLMM
+3 3 E
+MMRMMRMRRM`
+	if _, err := Simulate(input, &Options{Limits: &Limits{MaxArea: 36, MaxRobots: 2, MaxCommands: 10, MaxSteps: 19}}); err != nil {
+		t.Fatalf("Simulate() should not have exceeded any limits - got %v instead", err)
+	}
+	testLimit(t, input, &Limits{MaxArea: 35}, AreaLimit)
+	testLimit(t, "9223372036854775807 9223372036854775807\n1 2 N\nM", &Limits{MaxArea: 35}, AreaLimit)
+	testLimit(t, input, &Limits{MaxRobots: 1}, RobotsLimit)
+	testLimit(t, input, &Limits{MaxCommands: 9}, CommandsLimit)
+	m := testLimit(t, input, &Limits{MaxSteps: 12}, StepsLimit)
+	if m.String() != "1 3 N" || len(m.Tracks[1].Steps) != 3 {
+		t.Fatalf("expected robot ID 2 to halt after 3 steps - got %s instead", m.String())
+	}
+}
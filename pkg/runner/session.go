@@ -0,0 +1,227 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+	"github.com/juubisnake/mars-rover/internal/pkg/travel"
+)
+
+// Session holds a surface and the robots deployed upon it between batches of commands, so
+// that robots can be deployed and guided incrementally rather than all at once.
+// Every robot is named when it is deployed, and is given an ID in the order it was deployed.
+//
+// Unlike Run, a robot within a session may not move into a cell occupied by another robot
+// that is still on the surface. A robot that moves out of bounds is lost and is no longer
+// on the surface, but remains within the session's state.
+//
+// A Session is safe for concurrent use.
+type Session struct {
+	mu       sync.Mutex
+	opts     *Options
+	mission  *Mission
+	robots   map[string]*manager
+	names    []string
+	occupied map[cell]int
+}
+
+// RobotState is the current state of a robot within a session.
+type RobotState struct {
+	Name  string
+	ID    int
+	Pose  Pose
+	Steps int
+	Lost  bool
+}
+
+// NewSession creates a session upon the surface described by an instruction, for example
+// "5 5", or upon Options.Surface whenever it is given, in which case the instruction is ignored.
+// A nil Options will use the default durations with no limits.
+func NewSession(surface string, opts *Options) (*Session, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if err := opts.Grid.check(); err != nil {
+		return nil, err
+	}
+	if err := opts.Motion.check(); err != nil {
+		return nil, err
+	}
+	if err := opts.checkSpeeds(); err != nil {
+		return nil, err
+	}
+	s := opts.Surface
+	if s == nil {
+		var err error
+		if s, err = buildSurface(surface, opts.Grid); err != nil {
+			return nil, err
+		}
+	}
+	if err := opts.Limits.check([]plateau.Surface{s}, nil); err != nil {
+		return nil, err
+	}
+	mission := &Mission{Surface: s, Grid: opts.Grid, Elevation: opts.Elevation}
+	opts.Journal.recordMission(mission)
+	return &Session{
+		opts:     opts,
+		mission:  mission,
+		robots:   make(map[string]*manager),
+		occupied: make(map[cell]int),
+	}, nil
+}
+
+// Surface returns the surface the session's robots are deployed upon.
+func (s *Session) Surface() plateau.Surface {
+	return s.mission.Surface
+}
+
+// Deploy places a named robot upon the surface given an instruction, for example "1 2 N".
+// It returns a RobotCollisionError if the cell is already occupied by another robot.
+func (s *Session) Deploy(name, position string) (RobotState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, err := s.deploy(name, position)
+	if err != nil {
+		s.opts.Journal.recordError(nil, err)
+		s.opts.logger().LogAttrs(context.Background(), slog.LevelError, "robot deployment failed", slog.String("name", name), ErrorAttr(err))
+		return state, err
+	}
+	s.opts.logger().LogAttrs(context.Background(), slog.LevelInfo, "robot deployed", slog.String("name", name), slog.Int("id", state.ID), slog.String("pose", state.Pose.String()))
+	return state, nil
+}
+
+// deploy places a named robot upon the surface; see Deploy.
+func (s *Session) deploy(name, position string) (RobotState, error) {
+	if _, ok := s.robots[name]; ok {
+		return RobotState{}, &DuplicateRobotError{Name: name}
+	}
+	if l := s.opts.Limits; l != nil && l.MaxRobots > 0 && len(s.robots) >= l.MaxRobots {
+		return RobotState{}, &LimitError{Limit: RobotsLimit, Max: l.MaxRobots}
+	}
+	m := &manager{ctx: context.Background(), opts: s.opts, surface: s.mission.Surface, mission: s.mission}
+	robot, err := m.BuildRobot(len(s.names), position)
+	if err != nil {
+		return RobotState{}, err
+	}
+	placed := cellOf(robot)
+	if other, ok := s.occupied[placed]; ok {
+		return RobotState{}, &RobotCollisionError{ID: robot.GetID(), OtherID: other, X: placed.x, Y: placed.y}
+	}
+	m.robot = robot
+	m.durations = s.opts.durationsFor(robot)
+	m.climb = s.opts.climbFor(robot.GetID())
+	m.speed = s.opts.speedFor(robot)
+	m.track = &Track{ID: robot.GetID(), Type: typeName(robot), Start: poseOf(robot), Placed: s.mission.taken}
+	s.mission.Tracks = append(s.mission.Tracks, m.track)
+	s.robots[name] = m
+	s.names = append(s.names, name)
+	s.occupied[placed] = robot.GetID()
+	m.deployed(name)
+	return s.state(name), nil
+}
+
+// Command sends a batch of commands to the named robot, returning its state once the commands
+// have been carried out.
+// Every command is parsed before any is carried out. If a move would take the robot into a cell
+// occupied by another robot, the move is not carried out and a RobotCollisionError is returned;
+// if a move takes the robot out of bounds, the robot is lost and a RobotOutOfBoundsError is
+// returned. In either case the commands that follow are not carried out.
+func (s *Session) Command(name, commands string) (RobotState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, err := s.command(name, commands)
+	if err != nil {
+		var id *int
+		if m, ok := s.robots[name]; ok {
+			id = &state.ID
+			m.raised(err)
+		}
+		s.opts.Journal.recordError(id, err)
+		s.opts.logger().LogAttrs(context.Background(), slog.LevelError, "robot command failed", slog.String("name", name), ErrorAttr(err))
+		return state, err
+	}
+	s.opts.logger().LogAttrs(context.Background(), slog.LevelInfo, "robot commanded", slog.String("name", name), slog.Int("id", state.ID), slog.String("pose", state.Pose.String()))
+	return state, nil
+}
+
+// command sends a batch of commands to the named robot; see Command.
+func (s *Session) command(name, commands string) (RobotState, error) {
+	m, ok := s.robots[name]
+	if !ok {
+		return RobotState{}, &UnknownRobotError{Name: name}
+	}
+	if m.track.Err != nil {
+		return s.state(name), &RobotLostError{Name: name, ID: m.robot.GetID()}
+	}
+	fmtdCommands := strings.TrimSpace(commands)
+	if l := s.opts.Limits; l != nil && l.MaxCommands > 0 && len(fmtdCommands) > l.MaxCommands {
+		return s.state(name), &LimitError{Limit: CommandsLimit, Max: l.MaxCommands}
+	}
+	moves := make([]travel.Movement, len(fmtdCommands))
+	for i := range fmtdCommands {
+		move, err := m.parseMovement(string(fmtdCommands[i]))
+		if err != nil {
+			return s.state(name), err
+		}
+		moves[i] = move
+	}
+	for _, move := range moves {
+		from := cellOf(m.robot)
+		path, _ := m.path(move, m.slip(move))
+		if at, other, ok := collision(s.occupied, from, path); ok {
+			return s.state(name), &RobotCollisionError{ID: m.robot.GetID(), OtherID: other, X: at.x, Y: at.y}
+		}
+		err := m.moveRobot(move)
+		if _, ok := err.(*RobotOutOfBoundsError); ok {
+			delete(s.occupied, from)
+			m.track.Err = err
+			return s.state(name), err
+		}
+		if err != nil {
+			return s.state(name), err
+		}
+		delete(s.occupied, from)
+		s.occupied[cellOf(m.robot)] = m.robot.GetID()
+	}
+	return s.state(name), nil
+}
+
+// State returns the current state of every robot within the session, in the order they were deployed.
+func (s *Session) State() []RobotState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make([]RobotState, len(s.names))
+	for i, name := range s.names {
+		states[i] = s.state(name)
+	}
+	return states
+}
+
+// Mission returns a copy of the record of every step taken by the session's robots so far, which can be
+// used to render or export the session.
+func (s *Session) Mission() *Mission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mission := &Mission{Surface: s.mission.Surface, Grid: s.mission.Grid, Atlas: s.mission.Atlas, Elevation: s.mission.Elevation, taken: s.mission.taken}
+	for _, t := range s.mission.Tracks {
+		track := *t
+		track.Steps = append([]Step(nil), t.Steps...)
+		mission.Tracks = append(mission.Tracks, &track)
+	}
+	return mission
+}
+
+// state returns the current state of the named robot.
+func (s *Session) state(name string) RobotState {
+	m := s.robots[name]
+	return RobotState{
+		Name:  name,
+		ID:    m.robot.GetID(),
+		Pose:  poseOf(m.robot),
+		Steps: len(m.track.Steps),
+		Lost:  m.track.Err != nil,
+	}
+}
@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+)
+
+func testSession(t *testing.T, surface string) *Session {
+	s, err := NewSession(surface, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSession_Batches(t *testing.T) {
+	s := testSession(t, "5 5")
+	if _, err := s.Deploy("spirit", "1 2 N"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Command("spirit", "LMLM"); err != nil {
+		t.Fatal(err)
+	}
+	state, err := s.Command("spirit", "LMLMM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Pose.String() != "1 3 N" || state.Steps != 9 {
+		t.Fatalf("expected batches to carry on from one another to 1 3 N after 9 steps - got %s after %d instead", state.Pose, state.Steps)
+	}
+	if _, err := s.Deploy("opportunity", "3 3 E"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Command("opportunity", "MMRMMRMRRM"); err != nil {
+		t.Fatal(err)
+	}
+	states := s.State()
+	if len(states) != 2 || states[1].ID != 1 || states[1].Pose.String() != "5 1 E" {
+		t.Fatalf("expected the second robot to finish at 5 1 E - got %+v instead", states)
+	}
+	if s.Mission().Steps() != 19 {
+		t.Fatalf("expected the session's mission to record 19 steps - got %d instead", s.Mission().Steps())
+	}
+}
+
+func TestSession_Collision(t *testing.T) {
+	s := testSession(t, "5 5")
+	if _, err := s.Deploy("spirit", "1 1 E"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Deploy("opportunity", "1 1 N"); err == nil {
+		t.Fatal("expected deploying into an occupied cell to fail")
+	}
+	if _, err := s.Deploy("opportunity", "3 1 W"); err != nil {
+		t.Fatal(err)
+	}
+	state, err := s.Command("opportunity", "MMM")
+	var collision *RobotCollisionError
+	if !errors.As(err, &collision) || collision.OtherID != 0 || collision.X != 1 {
+		t.Fatalf("expected a collision with the first robot at 1 1 - got %v instead", err)
+	}
+	if state.Pose.String() != "2 1 W" {
+		t.Fatalf("expected the robot to stop short of the collision at 2 1 W - got %s instead", state.Pose)
+	}
+}
+
+func TestSession_Lost(t *testing.T) {
+	s := testSession(t, "2 2")
+	if _, err := s.Deploy("spirit", "0 0 S"); err != nil {
+		t.Fatal(err)
+	}
+	state, err := s.Command("spirit", "M")
+	var outOfBounds *RobotOutOfBoundsError
+	if !errors.As(err, &outOfBounds) || !state.Lost {
+		t.Fatalf("expected the robot to be lost out of bounds - got %v instead", err)
+	}
+	var lost *RobotLostError
+	if _, err := s.Command("spirit", "M"); !errors.As(err, &lost) {
+		t.Fatalf("expected commands to a lost robot to fail - got %v instead", err)
+	}
+	if _, err := s.Deploy("opportunity", "0 0 N"); err != nil {
+		t.Fatalf("expected a lost robot to no longer occupy its cell - got %v instead", err)
+	}
+}
+
+func TestSession_Errors(t *testing.T) {
+	s := testSession(t, "5 5")
+	var unknown *UnknownRobotError
+	if _, err := s.Command("spirit", "M"); !errors.As(err, &unknown) {
+		t.Fatalf("expected an unknown robot error - got %v instead", err)
+	}
+	if _, err := s.Deploy("spirit", "1 1 N"); err != nil {
+		t.Fatal(err)
+	}
+	var duplicate *DuplicateRobotError
+	if _, err := s.Deploy("spirit", "2 2 N"); !errors.As(err, &duplicate) {
+		t.Fatalf("expected a duplicate robot error - got %v instead", err)
+	}
+	var movement *ParseRobotMovementError
+	state, err := s.Command("spirit", "MMX")
+	if !errors.As(err, &movement) || state.Steps != 0 {
+		t.Fatalf("expected an invalid batch to be rejected before any command is carried out - got %v after %d steps instead", err, state.Steps)
+	}
+}
+
+func TestSession_Limits(t *testing.T) {
+	s, err := NewSession("5 5", &Options{Limits: &Limits{MaxRobots: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Deploy("spirit", "1 1 N"); err != nil {
+		t.Fatal(err)
+	}
+	var limit *LimitError
+	if _, err := s.Deploy("opportunity", "2 2 N"); !errors.As(err, &limit) || limit.Limit != RobotsLimit {
+		t.Fatalf("expected the robots limit to be hit - got %v instead", err)
+	}
+}
+
+func TestSession_Speed(t *testing.T) {
+	s, err := NewSession("5 5", &Options{Speed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Deploy("spirit", "0 1 E")
+	s.Deploy("opportunity", "2 1 N")
+	state, err := s.Command("spirit", "M")
+	var collision *RobotCollisionError
+	if !errors.As(err, &collision) || collision.OtherID != 1 || collision.X != 2 || collision.Y != 1 {
+		t.Fatalf("expected a collision with the second robot at 2 1 - got %v instead", err)
+	}
+	if state.Pose.String() != "0 1 E" {
+		t.Fatalf("expected the robot not to move - got %s instead", state.Pose)
+	}
+}
@@ -0,0 +1,159 @@
+package runner
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/robot"
+)
+
+// cell is a coordinate within a surface, along with the name of the surface whenever a
+// mission spans more than one.
+type cell struct {
+	plateau string
+	x, y    int
+}
+
+// cellOf returns the cell a given robot occupies.
+func cellOf(r *robot.Robot) cell {
+	return cell{r.GetPlateau(), r.GetX(), r.GetY()}
+}
+
+// simulateSimultaneous places every robot upon the surface before advancing each robot by
+// one command per tick, until every robot has carried out all of its commands.
+//
+// Each robot keeps its own clock, and the robot whose clock is the earliest carries out its
+// next command; with equal durations this is one command per robot per tick. Robots that are
+// ready at the same time act in ascending order of ID, so a robot with a lower ID has priority
+// over a robot with a higher ID.
+//
+// A robot whose move would take it into or through a cell occupied by another robot waits for as long as
+// the move would have taken, or until the clock of the robot blocking it whenever that is later,
+// before retrying the same command; a robot that is waiting acts after any robot that is not
+// waiting whose clock is the same. This resolves two robots moving into the same cell, since the
+// robot that acts first takes the cell and the other waits until it has moved on.
+// If every robot that has commands remaining waits before any other command is carried out,
+// none of them will ever be able to move and the mission halts with a RobotDeadlockError. Waiting
+// never resolves two robots attempting to swap cells head-on, since each waits for the other to
+// move, so a head-on swap always halts the mission with a RobotDeadlockError.
+//
+// Robots may not be placed within the same cell; doing so halts the mission with a
+// RobotCollisionError before any robot has moved.
+func simulateSimultaneous(ctx context.Context, mission *Mission, instructions []string, opts *Options) error {
+	var managers []*manager
+	var commands []string
+	occupied := make(map[cell]int)
+	for i := 0; i+1 < len(instructions); i += 2 {
+		if err := ctx.Err(); err != nil {
+			return &CancelledError{Err: err}
+		}
+		m := &manager{ctx: ctx, opts: opts, surface: mission.Surface, atlas: mission.Atlas, mission: mission}
+		robot, err := m.BuildRobot(i, instructions[i])
+		if err != nil {
+			opts.unplaced(i, err)
+			return err
+		}
+		position := cellOf(robot)
+		if other, ok := occupied[position]; ok {
+			err := &RobotCollisionError{ID: robot.GetID(), OtherID: other, Plateau: position.plateau, X: position.x, Y: position.y}
+			opts.unplaced(i, err)
+			return err
+		}
+		occupied[position] = robot.GetID()
+		m.robot = robot
+		m.durations = opts.durationsFor(robot)
+		m.climb = opts.climbFor(robot.GetID())
+		m.speed = opts.speedFor(robot)
+		m.track = &Track{ID: robot.GetID(), Type: typeName(robot), Start: poseOf(robot)}
+		mission.Tracks = append(mission.Tracks, m.track)
+		m.deployed("")
+		managers = append(managers, m)
+		commands = append(commands, strings.TrimSpace(instructions[i+1]))
+	}
+
+	byID := make(map[int]*manager, len(managers))
+	for _, m := range managers {
+		byID[m.robot.GetID()] = m
+	}
+	next := make([]int, len(managers))
+	waiting := make(map[int]bool)
+	for n := 1; ; n++ {
+		if n%cancellationInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return &CancelledError{Err: err}
+			}
+		}
+		var active int
+		current := -1
+		for i, m := range managers {
+			if next[i] == len(commands[i]) {
+				if !m.track.Done {
+					m.finished()
+				}
+				continue
+			}
+			active++
+			if current == -1 || earlier(m, managers[current], waiting) {
+				current = i
+			}
+		}
+		if current == -1 {
+			return nil
+		}
+		m := managers[current]
+		move, err := m.parseMovement(string(commands[current][next[current]]))
+		if err != nil {
+			m.halt(err)
+			return err
+		}
+		from := cellOf(m.robot)
+		path, _ := m.path(move, m.slip(move))
+		if _, other, ok := collision(occupied, from, path); ok {
+			if err := m.tick(move); err != nil {
+				m.halt(err)
+				return err
+			}
+			// a robot cannot move until the robot blocking it has acted again, which is never
+			// before that robot's clock.
+			if blocker := byID[other]; m.clock < blocker.clock {
+				m.clock = blocker.clock
+			}
+			waiting[m.robot.GetID()] = true
+			if len(waiting) == active {
+				return &RobotDeadlockError{IDs: sortedIDs(waiting)}
+			}
+			continue
+		}
+		if err := m.moveRobot(move); err != nil {
+			m.halt(err)
+			return err
+		}
+		delete(occupied, from)
+		occupied[cellOf(m.robot)] = m.robot.GetID()
+		next[current]++
+		waiting = make(map[int]bool)
+	}
+}
+
+// earlier checks if the robot of manager a should act before the robot of manager b. The robot
+// whose clock is the earliest acts first, and of two robots whose clocks are equal a robot that
+// is waiting acts after one that is not, so that a waiting robot whose clock never moves forward,
+// such as when moving takes no time, cannot keep acting ahead of the robot blocking it.
+// Otherwise the robot that was deployed first acts first.
+func earlier(a, b *manager, waiting map[int]bool) bool {
+	if a.clock != b.clock {
+		return a.clock < b.clock
+	}
+	return !waiting[a.robot.GetID()] && waiting[b.robot.GetID()]
+}
+
+// sortedIDs returns the robot IDs within the given set in ascending order.
+func sortedIDs(ids map[int]bool) []int {
+	var sorted []int
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
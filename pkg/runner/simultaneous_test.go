@@ -0,0 +1,198 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testSimultaneous(t *testing.T, input string) *Mission {
+	m, err := Simulate(input, &Options{Simultaneous: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSimulate_Simultaneous_Example(t *testing.T) {
+	input := `
+5 5
+1 2 N
+LMLMLMLMM
+3 3 E
+MMRMMRMRRM`
+	m := testSimultaneous(t, input)
+	if m.String() != "1 3 N\n5 1 E" {
+		t.Fatalf("expected robots that never meet to finish where they would sequentially - got %s instead", m.String())
+	}
+	if m.Tracks[1].Placed != 0 || m.Tracks[1].Steps[0].Seq != 2 {
+		t.Fatal("expected the second robot to be placed before any step and to take the second step")
+	}
+}
+
+func TestSimulate_Simultaneous_SameCell(t *testing.T) {
+	input := `
+2 2
+0 1 E
+MM
+1 0 N
+MM`
+	m := testSimultaneous(t, input)
+	if m.String() != "2 1 E\n1 2 N" {
+		t.Fatalf("expected robot ID 0 to take priority over robot ID 2 - got %s instead", m.String())
+	}
+	seqs := []int{m.Tracks[1].Steps[0].Seq, m.Tracks[1].Steps[1].Seq}
+	if seqs[0] != 3 || seqs[1] != 4 {
+		t.Fatalf("expected robot ID 2 to wait a tick before moving - took steps %v instead", seqs)
+	}
+}
+
+func TestSimulate_Simultaneous_Follow(t *testing.T) {
+	input := `
+2 0
+1 0 E
+M
+0 0 E
+M`
+	m := testSimultaneous(t, input)
+	if m.String() != "2 0 E\n1 0 E" || m.Tracks[1].Steps[0].Seq != 2 {
+		t.Fatalf("expected robot ID 2 to follow robot ID 0 within the same tick - got %s instead", m.String())
+	}
+	input = `
+2 0
+0 0 E
+M
+1 0 E
+M`
+	m = testSimultaneous(t, input)
+	if m.String() != "1 0 E\n2 0 E" || m.Tracks[0].Steps[0].Seq != 2 {
+		t.Fatalf("expected robot ID 0 to wait for robot ID 2 to move - got %s instead", m.String())
+	}
+}
+
+func TestSimulate_Simultaneous_Deadlock(t *testing.T) {
+	input := `
+3 0
+0 0 E
+MM
+1 0 W
+M`
+	m, err := Simulate(input, &Options{Simultaneous: true})
+	var de *RobotDeadlockError
+	if !errors.As(err, &de) {
+		t.Fatalf("Simulate() should have produced a RobotDeadlockError - got %T instead", err)
+	}
+	if len(de.IDs) != 2 || de.IDs[0] != 0 || de.IDs[1] != 2 {
+		t.Fatalf("expected robot IDs 0 and 2 to be deadlocked - got %v instead", de.IDs)
+	}
+	if m.String() != "" {
+		t.Fatalf("expected no robot to have finished - got %s instead", m.String())
+	}
+}
+
+func TestSimulate_Simultaneous_Collision(t *testing.T) {
+	input := `
+3 3
+1 1 E
+M
+1 1 W
+M`
+	_, err := Simulate(input, &Options{Simultaneous: true})
+	var ce *RobotCollisionError
+	if !errors.As(err, &ce) {
+		t.Fatalf("Simulate() should have produced a RobotCollisionError - got %T instead", err)
+	}
+	if ce.ID != 2 || ce.OtherID != 0 || ce.X != 1 || ce.Y != 1 {
+		t.Fatalf("expected robot ID 2 to collide with robot ID 0 at 1 1 - got %v instead", ce)
+	}
+}
+
+func TestSimulate_Simultaneous_OutOfBounds(t *testing.T) {
+	input := `
+3 3
+0 0 N
+MMMM
+3 3 S
+MM`
+	m, err := Simulate(input, &Options{Simultaneous: true})
+	var oe *RobotOutOfBoundsError
+	if !errors.As(err, &oe) {
+		t.Fatalf("Simulate() should have produced a RobotOutOfBoundsError - got %T instead", err)
+	}
+	if m.String() != "3 1 S" {
+		t.Fatalf("expected only robot ID 2 to have finished - got %s instead", m.String())
+	}
+}
+
+func TestSimulate_Simultaneous_Plateaus(t *testing.T) {
+	input := `
+plateau alpha 2 0
+plateau beta 2 0
+portal alpha 2 0 beta 0 0 E
+alpha 0 0 E
+MM
+beta 0 0 E
+LRM`
+	mission, err := Simulate(input, &Options{Simultaneous: true})
+	if err != nil {
+		t.Fatalf("robots upon the same cell of different plateaus should not collide - got %v instead", err)
+	}
+	if expected := "beta 0 0 E\nbeta 1 0 E"; mission.String() != expected {
+		t.Fatalf("expected the first robot to wait for the portal's entry cell to be freed - got %s instead", mission.String())
+	}
+}
+
+func TestSimulate_Simultaneous_Speed(t *testing.T) {
+	input := `
+3 3
+0 0 E
+M
+1 0 N
+M`
+	m, err := Simulate(input, &Options{Simultaneous: true, Speed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.String() != "3 0 E\n1 3 N" {
+		t.Fatalf("expected robot ID 0 to wait for robot ID 2 to move out of its path - got %s instead", m.String())
+	}
+	if seq := m.Tracks[0].Steps[0].Seq; seq != 2 {
+		t.Fatalf("expected robot ID 0 to take the second step - took step %d instead", seq)
+	}
+}
+
+func TestSimulate_Simultaneous_ZeroDurations(t *testing.T) {
+	input := `
+3 0
+0 0 E
+M
+1 0 E
+M`
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	m, err := SimulateContext(ctx, input, &Options{Simultaneous: true, Durations: &Durations{}})
+	if err != nil {
+		t.Fatalf("expected robot ID 0 to wait for robot ID 2 to move when moving takes no time - got %v instead", err)
+	}
+	if m.String() != "1 0 E\n2 0 E" || m.Tracks[0].Steps[0].Seq != 2 {
+		t.Fatalf("expected robot ID 0 to follow robot ID 2 - got %s instead", m.String())
+	}
+}
+
+func TestSimulate_Simultaneous_HeadOnSwap(t *testing.T) {
+	input := `
+1 0
+0 0 E
+M
+1 0 W
+M`
+	m, err := Simulate(input, &Options{Simultaneous: true})
+	var de *RobotDeadlockError
+	if !errors.As(err, &de) {
+		t.Fatalf("expected a head-on swap to deadlock - got %v instead", err)
+	}
+	if len(m.Tracks[0].Steps) != 0 || len(m.Tracks[1].Steps) != 0 {
+		t.Fatal("expected neither robot to have moved")
+	}
+}
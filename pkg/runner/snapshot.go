@@ -0,0 +1,374 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+	"github.com/juubisnake/mars-rover/internal/pkg/robot"
+)
+
+// SnapshotVersion is the version of the snapshot format written by Session.Snapshot; a snapshot
+// of any other version is rejected when restored.
+const SnapshotVersion = 1
+
+// Snapshot is the full state of a session, which can be written to disk and later restored so
+// that the session can continue where it left off.
+// Steps is the number of steps taken within the session when the snapshot was taken.
+type Snapshot struct {
+	Version int             `json:"version"`
+	Surface SnapshotSurface `json:"surface"`
+	Steps   int             `json:"steps"`
+	Robots  []SnapshotRobot `json:"robots"`
+}
+
+// SnapshotSurface is the surface of a session. A rectangle is given by its upper-right
+// boundary along with its lower-left boundary whenever it is not 0,0; a polygon is given by
+// its vertices, a cell mask by its rows along with its lower-left boundary, and a hexagon by its
+// radius along with its bounds. Heightmap is the elevation of the surface, if any, and Grid is
+// the topology of its cells whenever it is not the default.
+type SnapshotSurface struct {
+	Grid      Grid               `json:"grid,omitempty"`
+	LowerX    int                `json:"lower_x,omitempty"`
+	LowerY    int                `json:"lower_y,omitempty"`
+	X         int                `json:"x"`
+	Y         int                `json:"y"`
+	Polygon   [][2]int           `json:"polygon,omitempty"`
+	Mask      []string           `json:"mask,omitempty"`
+	Radius    *int               `json:"radius,omitempty"`
+	Heightmap *SnapshotHeightmap `json:"heightmap,omitempty"`
+}
+
+// SnapshotHeightmap is the elevation of a surface, given by its rows of heights starting with
+// the top row, along with its lower-left boundary.
+type SnapshotHeightmap struct {
+	LowerX int     `json:"lower_x"`
+	LowerY int     `json:"lower_y"`
+	Rows   [][]int `json:"rows"`
+}
+
+// snapshotSurface converts a surface upon the given grid, along with its heightmap if any, into
+// its snapshot form.
+func snapshotSurface(s plateau.Surface, h *plateau.Heightmap, grid Grid) SnapshotSurface {
+	b := s.Bounds()
+	snapshot := SnapshotSurface{Grid: grid, LowerX: b.LowerBoundX, LowerY: b.LowerBoundY, X: b.UpperBoundX, Y: b.UpperBoundY}
+	if h != nil {
+		snapshot.Heightmap = &SnapshotHeightmap{LowerX: h.Bounds().LowerBoundX, LowerY: h.Bounds().LowerBoundY, Rows: h.Rows()}
+	}
+	switch s := s.(type) {
+	case *plateau.Polygon:
+		for _, v := range s.Vertices() {
+			snapshot.Polygon = append(snapshot.Polygon, [2]int{v.X, v.Y})
+		}
+	case *plateau.Mask:
+		snapshot.Mask = s.Rows()
+	case *plateau.Hexagon:
+		radius := s.Radius()
+		snapshot.Radius = &radius
+	}
+	return snapshot
+}
+
+// restoreSurface converts a surface, along with its heightmap if any, from its snapshot form.
+func restoreSurface(s SnapshotSurface) (plateau.Surface, *plateau.Heightmap, error) {
+	var surface plateau.Surface
+	var heightmap *plateau.Heightmap
+	var err error
+	switch {
+	case len(s.Polygon) > 0:
+		vertices := make([]plateau.Point, len(s.Polygon))
+		for i, v := range s.Polygon {
+			vertices[i] = plateau.Point{X: v[0], Y: v[1]}
+		}
+		surface, err = plateau.NewPolygon(vertices)
+	case len(s.Mask) > 0:
+		surface, err = plateau.NewMask(s.LowerX, s.LowerY, s.Mask)
+	case s.Radius != nil:
+		surface, err = plateau.NewHexagon(s.LowerX+*s.Radius, s.LowerY+*s.Radius, *s.Radius)
+	default:
+		surface, err = plateau.NewWithLowerBounds(s.LowerX, s.LowerY, s.X, s.Y)
+	}
+	if err == nil && s.Heightmap != nil {
+		heightmap, err = plateau.NewHeightmap(s.Heightmap.LowerX, s.Heightmap.LowerY, s.Heightmap.Rows)
+	}
+	if err != nil {
+		return nil, nil, &SurfaceError{Err: err}
+	}
+	return surface, heightmap, nil
+}
+
+// SnapshotPose is a robot's pose within a snapshot.
+type SnapshotPose struct {
+	Plateau   string `json:"plateau,omitempty"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Direction string `json:"direction"`
+}
+
+// String is a representation of a given pose in the same form as Pose.String.
+func (p SnapshotPose) String() string {
+	if p.Plateau != "" {
+		return fmt.Sprintf("%s %d %d %s", p.Plateau, p.X, p.Y, p.Direction)
+	}
+	return fmt.Sprintf("%d %d %s", p.X, p.Y, p.Direction)
+}
+
+// SnapshotPortal is a portal linking a cell upon one plateau to the pose a robot enters
+// another plateau with.
+type SnapshotPortal struct {
+	From  string       `json:"from"`
+	X     int          `json:"x"`
+	Y     int          `json:"y"`
+	Entry SnapshotPose `json:"entry"`
+}
+
+// snapshotPortal converts a portal into its snapshot form.
+func snapshotPortal(p plateau.Portal) SnapshotPortal {
+	return SnapshotPortal{
+		From:  p.From,
+		X:     p.At.X,
+		Y:     p.At.Y,
+		Entry: SnapshotPose{Plateau: p.To, X: p.Entry.X, Y: p.Entry.Y, Direction: string(p.Direction)},
+	}
+}
+
+// restorePortal converts a portal upon the given grid from its snapshot form.
+func restorePortal(grid Grid, p SnapshotPortal) (plateau.Portal, error) {
+	direction, err := grid.parseDirection(p.Entry.Direction)
+	if err != nil {
+		return plateau.Portal{}, &PlateauInstructionError{Instruction: fmt.Sprintf("%s %d %d %s", p.From, p.X, p.Y, p.Entry), Err: err}
+	}
+	return plateau.Portal{
+		From:      p.From,
+		At:        plateau.Point{X: p.X, Y: p.Y},
+		To:        p.Entry.Plateau,
+		Entry:     plateau.Point{X: p.Entry.X, Y: p.Entry.Y},
+		Direction: direction,
+	}, nil
+}
+
+// SnapshotObstacle is a cell holding an obstacle.
+type SnapshotObstacle struct {
+	Plateau string `json:"plateau,omitempty"`
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+}
+
+// SnapshotType is a type of rover along with what it is capable of, where Commands holds each
+// movement it can carry out, I.E "LRM". Turn is in nanoseconds.
+type SnapshotType struct {
+	Name             string        `json:"name"`
+	Step             int           `json:"step"`
+	Commands         string        `json:"commands"`
+	CrossesObstacles bool          `json:"crosses_obstacles,omitempty"`
+	Turn             time.Duration `json:"turn,omitempty"`
+}
+
+// snapshotType converts a type of rover into its snapshot form.
+func snapshotType(t *robot.Type) SnapshotType {
+	var commands strings.Builder
+	for _, c := range t.Commands {
+		commands.WriteString(string(c))
+	}
+	return SnapshotType{Name: t.Name, Step: t.Step, Commands: commands.String(), CrossesObstacles: t.CrossesObstacles, Turn: t.Turn}
+}
+
+// restoreType converts a type of rover from its snapshot form.
+func restoreType(t SnapshotType) (*robot.Type, error) {
+	obstacles := avoidObstacles
+	if t.CrossesObstacles {
+		obstacles = crossObstacles
+	}
+	config := []string{t.Name, strconv.Itoa(t.Step), t.Commands, obstacles, t.Turn.String()}
+	return buildType(strings.Join(append([]string{typeKeyword}, config...), " "), config)
+}
+
+// SnapshotStep is a single recorded step within a robot's history. Time is in nanoseconds.
+type SnapshotStep struct {
+	Command string        `json:"command"`
+	Pose    SnapshotPose  `json:"pose"`
+	Seq     int           `json:"seq"`
+	Time    time.Duration `json:"time"`
+	Climb   int           `json:"climb,omitempty"`
+	Slip    Slip          `json:"slip,omitempty"`
+}
+
+// SnapshotRobot is the state of a single robot within a snapshot.
+// Clock is how long after the start of the session the robot finished its last command, and
+// Started how long after the start of the session it was deployed, both in nanoseconds.
+// History is only present when the snapshot was taken with history.
+type SnapshotRobot struct {
+	Name    string         `json:"name"`
+	ID      int            `json:"id"`
+	Pose    SnapshotPose   `json:"pose"`
+	Lost    bool           `json:"lost,omitempty"`
+	Start   SnapshotPose   `json:"start"`
+	Placed  int            `json:"placed"`
+	Started time.Duration  `json:"started"`
+	Clock   time.Duration  `json:"clock"`
+	History []SnapshotStep `json:"history,omitempty"`
+}
+
+// Snapshot returns the current state of the session, including every step taken by each robot
+// when history is true.
+func (s *Session) Snapshot(history bool) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Surface: snapshotSurface(s.mission.Surface, s.mission.Elevation[""], s.mission.Grid),
+		Steps:   s.mission.taken,
+		Robots:  make([]SnapshotRobot, len(s.names)),
+	}
+	for i, name := range s.names {
+		m := s.robots[name]
+		r := SnapshotRobot{
+			Name:    name,
+			ID:      m.robot.GetID(),
+			Pose:    snapshotPose(poseOf(m.robot)),
+			Lost:    m.track.Err != nil,
+			Start:   snapshotPose(m.track.Start),
+			Placed:  m.track.Placed,
+			Started: m.track.Started,
+			Clock:   m.clock,
+		}
+		if history {
+			for _, step := range m.track.Steps {
+				r.History = append(r.History, SnapshotStep{Command: string(step.Command), Pose: snapshotPose(step.Pose), Seq: step.Seq, Time: step.Time, Climb: step.Climb, Slip: step.Slip})
+			}
+		}
+		snapshot.Robots[i] = r
+	}
+	return snapshot
+}
+
+// Write writes the snapshot to a given writer as JSON.
+func (s *Snapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ReadSnapshot reads a snapshot written by Snapshot.Write from a given reader.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, &SnapshotError{Reason: err.Error()}
+	}
+	return &snapshot, nil
+}
+
+// RestoreSession rebuilds the surface and robots of a session from a given snapshot, so that
+// robots can continue to be deployed and guided as if the session had never stopped.
+// The snapshot's heightmap, if any, takes the place of Options.Elevation.
+// A nil Options will use the default durations with no limits.
+func RestoreSession(snapshot *Snapshot, opts *Options) (*Session, error) {
+	if snapshot.Version != SnapshotVersion {
+		return nil, &SnapshotError{Reason: fmt.Sprintf("version %d is not supported, expected version %d", snapshot.Version, SnapshotVersion)}
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	if err := snapshot.Surface.Grid.check(); err != nil {
+		return nil, err
+	}
+	if err := opts.Motion.check(); err != nil {
+		return nil, err
+	}
+	if err := opts.checkSpeeds(); err != nil {
+		return nil, err
+	}
+	surface, heightmap, err := restoreSurface(snapshot.Surface)
+	if err != nil {
+		return nil, err
+	}
+	elevation := opts.Elevation
+	if heightmap != nil {
+		elevation = map[string]*plateau.Heightmap{"": heightmap}
+	}
+	s := &Session{
+		opts:     opts,
+		mission:  &Mission{Surface: surface, Grid: snapshot.Surface.Grid, Elevation: elevation, taken: snapshot.Steps},
+		robots:   make(map[string]*manager),
+		occupied: make(map[cell]int),
+	}
+	for i, r := range snapshot.Robots {
+		if r.ID != i {
+			return nil, &SnapshotError{Reason: fmt.Sprintf("robot '%s' has ID %d, expected ID %d", r.Name, r.ID, i)}
+		}
+		if _, ok := s.robots[r.Name]; ok {
+			return nil, &DuplicateRobotError{Name: r.Name}
+		}
+		pose, err := restorePose(s.mission.Grid, r.ID, r.Pose)
+		if err != nil {
+			return nil, err
+		}
+		start, err := restorePose(s.mission.Grid, r.ID, r.Start)
+		if err != nil {
+			return nil, err
+		}
+		restored := robot.New(r.ID, pose.X, pose.Y, pose.Direction)
+		m := &manager{
+			ctx:       context.Background(),
+			opts:      opts,
+			surface:   surface,
+			mission:   s.mission,
+			robot:     restored,
+			durations: opts.durationsFor(restored),
+			climb:     opts.climbFor(r.ID),
+			speed:     opts.speedFor(restored),
+			clock:     r.Clock,
+			track:     &Track{ID: r.ID, Start: start, Placed: r.Placed, Started: r.Started},
+		}
+		for _, step := range r.History {
+			stepPose, err := restorePose(s.mission.Grid, r.ID, step.Pose)
+			if err != nil {
+				return nil, err
+			}
+			move, err := m.parseMovement(step.Command)
+			if err != nil {
+				return nil, err
+			}
+			slip, err := parseSlip(string(step.Slip))
+			if err != nil {
+				return nil, &SnapshotError{Reason: err.Error()}
+			}
+			m.track.Steps = append(m.track.Steps, Step{Command: move, Pose: stepPose, Seq: step.Seq, Time: step.Time, Climb: step.Climb, Slip: slip})
+		}
+		if r.Lost {
+			m.track.Err = &RobotOutOfBoundsError{ID: r.ID, X: pose.X, Y: pose.Y}
+		} else {
+			if surface.IsOutOfBounds(pose.X, pose.Y) {
+				return nil, &RobotOutOfBoundsError{ID: r.ID, X: pose.X, Y: pose.Y}
+			}
+			placed := cellOf(m.robot)
+			if other, ok := s.occupied[placed]; ok {
+				return nil, &RobotCollisionError{ID: r.ID, OtherID: other, X: pose.X, Y: pose.Y}
+			}
+			s.occupied[placed] = r.ID
+		}
+		s.mission.Tracks = append(s.mission.Tracks, m.track)
+		s.robots[r.Name] = m
+		s.names = append(s.names, r.Name)
+	}
+	return s, nil
+}
+
+// snapshotPose converts a pose into its snapshot form.
+func snapshotPose(p Pose) SnapshotPose {
+	return SnapshotPose{Plateau: p.Plateau, X: p.X, Y: p.Y, Direction: string(p.Direction)}
+}
+
+// restorePose converts a pose upon the given grid from its snapshot form.
+func restorePose(grid Grid, id int, p SnapshotPose) (Pose, error) {
+	direction, err := grid.parseDirection(p.Direction)
+	if err != nil {
+		return Pose{}, &ParseRobotDirectionError{Direction: p.Direction, ID: id, Err: err}
+	}
+	return Pose{Plateau: p.Plateau, X: p.X, Y: p.Y, Direction: direction}, nil
+}
@@ -0,0 +1,180 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/juubisnake/mars-rover/internal/pkg/plateau"
+)
+
+func testRoundTrip(t *testing.T, s *Session, history bool) *Session {
+	var buf bytes.Buffer
+	if err := s.Snapshot(history).Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := RestoreSession(snapshot, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return restored
+}
+
+func testSnapshotSession(t *testing.T) *Session {
+	s := testSession(t, "5 5")
+	for _, deploy := range [][3]string{{"spirit", "1 2 N", "LMLMLM"}, {"opportunity", "3 3 E", "MMRMM"}, {"sojourner", "0 0 S", "M"}} {
+		if _, err := s.Deploy(deploy[0], deploy[1]); err != nil {
+			t.Fatal(err)
+		}
+		s.Command(deploy[0], deploy[2])
+	}
+	return s
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	s := testSnapshotSession(t)
+	restored := testRoundTrip(t, s, true)
+	if !reflect.DeepEqual(s.State(), restored.State()) {
+		t.Fatalf("expected the restored state to match - got %+v instead of %+v", restored.State(), s.State())
+	}
+	for _, batch := range [][2]string{{"spirit", "LMM"}, {"opportunity", "RMRRM"}, {"sojourner", "M"}} {
+		_, err := s.Command(batch[0], batch[1])
+		_, restoredErr := restored.Command(batch[0], batch[1])
+		if !reflect.DeepEqual(err, restoredErr) {
+			t.Fatalf("expected the restored session to fail identically - got %v instead of %v", restoredErr, err)
+		}
+	}
+	if _, err := restored.Deploy("curiosity", "0 0 N"); err != nil {
+		t.Fatalf("expected the lost robot's cell to be free once restored - got %v instead", err)
+	}
+	s.Deploy("curiosity", "0 0 N")
+	if !reflect.DeepEqual(s.Mission(), restored.Mission()) {
+		t.Fatal("expected the restored session to carry on identically to the original")
+	}
+}
+
+func TestSnapshot_WithoutHistory(t *testing.T) {
+	s := testSnapshotSession(t)
+	restored := testRoundTrip(t, s, false)
+	if restored.Mission().Steps() != 0 {
+		t.Fatalf("expected no steps to be restored without history - got %d instead", restored.Mission().Steps())
+	}
+	s.Command("spirit", "LMM")
+	state, err := restored.Command("spirit", "LMM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Pose != s.State()[0].Pose {
+		t.Fatalf("expected the restored robot to carry on to %s - got %s instead", s.State()[0].Pose, state.Pose)
+	}
+	if step := restored.Mission().Tracks[0].Steps[0]; step.Seq != 13 || step.Time != 7e9 {
+		t.Fatalf("expected the restored step counter and clock to carry on - got step %d at %s instead", step.Seq, step.Time)
+	}
+}
+
+func TestSnapshot_Invalid(t *testing.T) {
+	var snapshotErr *SnapshotError
+	if _, err := ReadSnapshot(strings.NewReader("{")); !errors.As(err, &snapshotErr) {
+		t.Fatalf("expected a snapshot error for invalid JSON - got %v instead", err)
+	}
+	if _, err := RestoreSession(&Snapshot{Version: SnapshotVersion + 1}, nil); !errors.As(err, &snapshotErr) {
+		t.Fatalf("expected a snapshot error for an unsupported version - got %v instead", err)
+	}
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Surface: SnapshotSurface{X: 5, Y: 5},
+		Robots: []SnapshotRobot{
+			{Name: "spirit", ID: 0, Pose: SnapshotPose{X: 1, Y: 1, Direction: "N"}, Start: SnapshotPose{X: 1, Y: 1, Direction: "N"}},
+			{Name: "opportunity", ID: 1, Pose: SnapshotPose{X: 1, Y: 1, Direction: "E"}, Start: SnapshotPose{X: 1, Y: 1, Direction: "E"}},
+		},
+	}
+	var collision *RobotCollisionError
+	if _, err := RestoreSession(snapshot, nil); !errors.As(err, &collision) {
+		t.Fatalf("expected a collision error for robots sharing a cell - got %v instead", err)
+	}
+	snapshot.Robots[1].Pose.Direction = "X"
+	var direction *ParseRobotDirectionError
+	if _, err := RestoreSession(snapshot, nil); !errors.As(err, &direction) {
+		t.Fatalf("expected a direction error for an invalid direction - got %v instead", err)
+	}
+}
+
+func TestSnapshot_LowerBounds(t *testing.T) {
+	s := testSession(t, "-5 -5 5 5")
+	if _, err := s.Deploy("spirit", "-5 -5 N"); err != nil {
+		t.Fatal(err)
+	}
+	restored := testRoundTrip(t, s, true)
+	surface := restored.Surface().Bounds()
+	if surface.LowerBoundX != -5 || surface.LowerBoundY != -5 || surface.UpperBoundX != 5 {
+		t.Fatalf("expected the surface's lower bounds to be restored - got %v instead", surface)
+	}
+	if _, err := restored.Command("spirit", "RRM"); !errors.As(err, new(*RobotOutOfBoundsError)) {
+		t.Fatalf("expected the restored robot to move out of bounds beyond the lower bound - got %v instead", err)
+	}
+}
+
+func TestSnapshot_Shapes(t *testing.T) {
+	mask, err := plateau.NewMask(-1, -1, []string{"..", ".#"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, opts := range []*Options{{}, {Surface: mask}} {
+		s, err := NewSession("0 0 4 0 4 2 2 2 2 4 0 4", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored := testRoundTrip(t, s, false)
+		if restored.Surface().String() != s.Surface().String() || !reflect.DeepEqual(restored.Surface(), s.Surface()) {
+			t.Fatalf("expected the surface to be restored - got %s instead of %s", restored.Surface(), s.Surface())
+		}
+	}
+}
+
+func TestSnapshot_Elevation(t *testing.T) {
+	heightmap, err := plateau.NewHeightmap(0, 0, [][]int{{2, 3}, {0, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSession("1 1", &Options{Elevation: map[string]*plateau.Heightmap{"": heightmap}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Deploy("spirit", "0 0 N")
+	s.Command("spirit", "MRM")
+	restored := testRoundTrip(t, s, true)
+	if !reflect.DeepEqual(restored.Mission(), s.Mission()) {
+		t.Fatalf("expected the elevation and history to be restored - got %s instead of %s", restored.Mission(), s.Mission())
+	}
+	if output := restored.Mission().String(); output != "" {
+		t.Fatalf("expected no robot to have finished within the session - got %s instead", output)
+	}
+	if _, err := restored.Command("spirit", "RRM"); err != nil {
+		t.Fatal(err)
+	}
+	if track := restored.Mission().Tracks[0]; track.Ascent() != 3 || track.Descent() != 1 {
+		t.Fatalf("expected the robot to have climbed 3 and descended 1 - got %d and %d instead", track.Ascent(), track.Descent())
+	}
+}
+
+func TestSnapshot_Hex(t *testing.T) {
+	s, err := NewSession("2", &Options{Grid: HexGrid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Deploy("spirit", "-1 1 SE")
+	s.Command("spirit", "MLM")
+	restored := testRoundTrip(t, s, true)
+	if !reflect.DeepEqual(restored.Mission(), s.Mission()) {
+		t.Fatalf("expected the hex grid and history to be restored - got %s instead of %s", restored.Mission(), s.Mission())
+	}
+	if _, err := restored.Command("spirit", "RM"); err != nil {
+		t.Fatal(err)
+	}
+}
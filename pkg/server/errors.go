@@ -0,0 +1,217 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+const (
+	// CodeMissingInputLines relates to runner.MissingInputLinesError.
+	CodeMissingInputLines = "missing_input_lines"
+	// CodeEvenInputLines relates to runner.EvenInputLinesError.
+	CodeEvenInputLines = "even_input_lines"
+	// CodeRobotInputLines relates to runner.RobotInputLinesError.
+	CodeRobotInputLines = "robot_input_lines"
+	// CodeUnknownGrid relates to runner.GridError.
+	CodeUnknownGrid = "unknown_grid"
+	// CodeInvalidSpeed relates to runner.SpeedError.
+	CodeInvalidSpeed = "invalid_speed"
+	// CodeInvalidMotion relates to runner.MotionError.
+	CodeInvalidMotion = "invalid_motion"
+	// CodeSurfaceDimension relates to runner.SurfaceDimensionError.
+	CodeSurfaceDimension = "surface_dimension"
+	// CodeParseSurfaceBoundary relates to runner.ParseSurfaceBoundaryError.
+	CodeParseSurfaceBoundary = "parse_surface_boundary"
+	// CodeInvalidSurface relates to runner.SurfaceError.
+	CodeInvalidSurface = "invalid_surface"
+	// CodePlateauInstruction relates to runner.PlateauInstructionError.
+	CodePlateauInstruction = "plateau_instruction"
+	// CodeUnknownPlateau relates to runner.UnknownPlateauError.
+	CodeUnknownPlateau = "unknown_plateau"
+	// CodeDeclaration relates to runner.DeclarationError.
+	CodeDeclaration = "declaration"
+	// CodeUnknownType relates to runner.UnknownTypeError.
+	CodeUnknownType = "unknown_type"
+	// CodeRobotInstructionLength relates to runner.RobotInstructionLengthError.
+	CodeRobotInstructionLength = "robot_instruction_length"
+	// CodeParseRobotCoordinate relates to runner.ParseRobotCoordinateError.
+	CodeParseRobotCoordinate = "parse_robot_coordinate"
+	// CodeParseRobotDirection relates to runner.ParseRobotDirectionError.
+	CodeParseRobotDirection = "parse_robot_direction"
+	// CodeParseRobotMovement relates to runner.ParseRobotMovementError.
+	CodeParseRobotMovement = "parse_robot_movement"
+	// CodeRobotOutOfBounds relates to runner.RobotOutOfBoundsError.
+	CodeRobotOutOfBounds = "robot_out_of_bounds"
+	// CodeRobotCommand relates to runner.RobotCommandError.
+	CodeRobotCommand = "robot_command"
+	// CodeRobotObstacle relates to runner.RobotObstacleError.
+	CodeRobotObstacle = "robot_obstacle"
+	// CodeRobotClimb relates to runner.RobotClimbError.
+	CodeRobotClimb = "robot_climb"
+	// CodeRobotCollision relates to runner.RobotCollisionError.
+	CodeRobotCollision = "robot_collision"
+	// CodeRobotDeadlock relates to runner.RobotDeadlockError.
+	CodeRobotDeadlock = "robot_deadlock"
+	// CodeRobotTimeout relates to runner.RobotTimeoutError.
+	CodeRobotTimeout = "robot_timeout"
+	// CodeLimitExceeded relates to runner.LimitError.
+	CodeLimitExceeded = "limit_exceeded"
+	// CodeCancelled relates to runner.CancelledError.
+	CodeCancelled = "cancelled"
+	// CodeInvalidRequest relates to a request body that cannot be decoded.
+	CodeInvalidRequest = "invalid_request"
+	// CodeUnsupportedMediaType relates to a request body that is neither text nor JSON.
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	// CodeMethodNotAllowed relates to a request made with the wrong method.
+	CodeMethodNotAllowed = "method_not_allowed"
+	// CodeInternal relates to any error that is not otherwise known.
+	CodeInternal = "internal"
+)
+
+// Error is the machine-readable representation of an error returned by the service.
+// RobotID and Limit are only present for errors that relate to a robot or limit respectively.
+type Error struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	RobotID *int         `json:"robot_id,omitempty"`
+	Limit   runner.Limit `json:"limit,omitempty"`
+}
+
+// classify maps an error returned by the runner onto a status code and a machine-readable error.
+//
+// Errors relating to an instruction-set that cannot be parsed are a 400, a mission that exceeds
+// its limits is a 413, a mission that is parsed but fails while running is a 422, and a mission
+// that is cancelled is a 503.
+func classify(err error) (int, *Error) {
+	e := &Error{Message: err.Error(), RobotID: robotID(err)}
+	var (
+		missing     *runner.MissingInputLinesError
+		even        *runner.EvenInputLinesError
+		robotLines  *runner.RobotInputLinesError
+		grid        *runner.GridError
+		speed       *runner.SpeedError
+		motion      *runner.MotionError
+		dimension   *runner.SurfaceDimensionError
+		boundary    *runner.ParseSurfaceBoundaryError
+		surface     *runner.SurfaceError
+		plateau     *runner.PlateauInstructionError
+		unknown     *runner.UnknownPlateauError
+		declaration *runner.DeclarationError
+		unknownType *runner.UnknownTypeError
+		length      *runner.RobotInstructionLengthError
+		coordinate  *runner.ParseRobotCoordinateError
+		direction   *runner.ParseRobotDirectionError
+		movement    *runner.ParseRobotMovementError
+		outOfBounds *runner.RobotOutOfBoundsError
+		command     *runner.RobotCommandError
+		obstacle    *runner.RobotObstacleError
+		climb       *runner.RobotClimbError
+		collision   *runner.RobotCollisionError
+		deadlock    *runner.RobotDeadlockError
+		timeout     *runner.RobotTimeoutError
+		limit       *runner.LimitError
+		cancelled   *runner.CancelledError
+	)
+	status := http.StatusBadRequest
+	switch {
+	case errors.As(err, &missing):
+		e.Code = CodeMissingInputLines
+	case errors.As(err, &even):
+		e.Code = CodeEvenInputLines
+	case errors.As(err, &robotLines):
+		e.Code = CodeRobotInputLines
+	case errors.As(err, &grid):
+		e.Code = CodeUnknownGrid
+	case errors.As(err, &speed):
+		e.Code = CodeInvalidSpeed
+	case errors.As(err, &motion):
+		e.Code = CodeInvalidMotion
+	case errors.As(err, &dimension):
+		e.Code = CodeSurfaceDimension
+	case errors.As(err, &boundary):
+		e.Code = CodeParseSurfaceBoundary
+	case errors.As(err, &surface):
+		e.Code = CodeInvalidSurface
+	case errors.As(err, &plateau):
+		e.Code = CodePlateauInstruction
+	case errors.As(err, &unknown):
+		e.Code = CodeUnknownPlateau
+	case errors.As(err, &declaration):
+		e.Code = CodeDeclaration
+	case errors.As(err, &unknownType):
+		e.Code = CodeUnknownType
+	case errors.As(err, &length):
+		e.Code = CodeRobotInstructionLength
+	case errors.As(err, &coordinate):
+		e.Code = CodeParseRobotCoordinate
+	case errors.As(err, &direction):
+		e.Code = CodeParseRobotDirection
+	case errors.As(err, &movement):
+		e.Code = CodeParseRobotMovement
+	case errors.As(err, &outOfBounds):
+		status, e.Code = http.StatusUnprocessableEntity, CodeRobotOutOfBounds
+	case errors.As(err, &command):
+		status, e.Code = http.StatusUnprocessableEntity, CodeRobotCommand
+	case errors.As(err, &obstacle):
+		status, e.Code = http.StatusUnprocessableEntity, CodeRobotObstacle
+	case errors.As(err, &climb):
+		status, e.Code = http.StatusUnprocessableEntity, CodeRobotClimb
+	case errors.As(err, &collision):
+		status, e.Code = http.StatusUnprocessableEntity, CodeRobotCollision
+	case errors.As(err, &deadlock):
+		status, e.Code = http.StatusUnprocessableEntity, CodeRobotDeadlock
+	case errors.As(err, &timeout):
+		status, e.Code = http.StatusUnprocessableEntity, CodeRobotTimeout
+	case errors.As(err, &limit):
+		status, e.Code, e.Limit = http.StatusRequestEntityTooLarge, CodeLimitExceeded, limit.Limit
+	case errors.As(err, &cancelled):
+		status, e.Code = http.StatusServiceUnavailable, CodeCancelled
+	default:
+		status, e.Code = http.StatusInternalServerError, CodeInternal
+	}
+	return status, e
+}
+
+// robotID returns the ID of the robot an error relates to, or nil if it does not relate to a robot.
+func robotID(err error) *int {
+	var id int
+	switch e := err.(type) {
+	case *runner.RobotInstructionLengthError:
+		id = e.ID
+	case *runner.ParseRobotCoordinateError:
+		id = e.ID
+	case *runner.ParseRobotDirectionError:
+		id = e.ID
+	case *runner.ParseRobotMovementError:
+		id = e.ID
+	case *runner.SpeedError:
+		return e.ID
+	case *runner.UnknownPlateauError:
+		id = e.ID
+	case *runner.UnknownTypeError:
+		id = e.ID
+	case *runner.RobotOutOfBoundsError:
+		id = e.ID
+	case *runner.RobotCommandError:
+		id = e.ID
+	case *runner.RobotObstacleError:
+		id = e.ID
+	case *runner.RobotClimbError:
+		id = e.ID
+	case *runner.RobotCollisionError:
+		id = e.ID
+	case *runner.RobotTimeoutError:
+		id = e.ID
+	default:
+		return nil
+	}
+	return &id
+}
+
+// Describe returns the machine-readable representation of an error returned by the runner.
+func Describe(err error) *Error {
+	_, e := classify(err)
+	return e
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juubisnake/mars-rover/pkg/metrics"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+// defaultMaxBodyBytes is the largest request body accepted when no maximum is given.
+const defaultMaxBodyBytes = 1 << 20
+
+// Options configures the service.
+type Options struct {
+	// Limits restricts the resources each mission may use; nil means there are no limits.
+	Limits *runner.Limits
+	// Timeout cancels any mission that runs for longer; zero means there is no timeout.
+	Timeout time.Duration
+	// Metrics records every mission that is run; nil means nothing is recorded.
+	Metrics *metrics.Registry
+	// Logger logs every mission that is run; nil means nothing is logged.
+	Logger *slog.Logger
+	// MaxBodyBytes is the largest request body accepted; defaults to 1MiB.
+	MaxBodyBytes int64
+}
+
+// MissionRequest is the JSON representation of a mission.
+// Either Instructions holds an instruction-set in the same format as runner.Run, or
+// Surface and Robots describe the mission. Grid is the topology of the surface's cells, either
+// square or hex, and defaults to square. Speed and RobotSpeeds are the number of cells each move
+// carries every robot and the robot with the given ID respectively, and Motion makes each command
+// liable to go awry; see runner.Options.
+type MissionRequest struct {
+	Instructions string          `json:"instructions,omitempty"`
+	Surface      *SurfaceRequest `json:"surface,omitempty"`
+	Robots       []RobotRequest  `json:"robots,omitempty"`
+	Simultaneous bool            `json:"simultaneous,omitempty"`
+	Grid         string          `json:"grid,omitempty"`
+	Speed        int             `json:"speed,omitempty"`
+	RobotSpeeds  map[int]int     `json:"robot_speeds,omitempty"`
+	Motion       *MotionRequest  `json:"motion,omitempty"`
+}
+
+// MotionRequest holds the seed and probabilities of a stochastic motion model; see runner.Motion.
+type MotionRequest struct {
+	Seed       uint64  `json:"seed"`
+	Stall      float64 `json:"stall,omitempty"`
+	Overshoot  float64 `json:"overshoot,omitempty"`
+	Drift      float64 `json:"drift,omitempty"`
+	OverRotate float64 `json:"over_rotate,omitempty"`
+}
+
+// SurfaceRequest holds the upper-right boundary of a surface, along with its lower-left boundary
+// which defaults to 0,0.
+type SurfaceRequest struct {
+	LowerX int `json:"lower_x,omitempty"`
+	LowerY int `json:"lower_y,omitempty"`
+	X      int `json:"x"`
+	Y      int `json:"y"`
+}
+
+// RobotRequest holds where a robot is placed and the commands it carries out.
+type RobotRequest struct {
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Direction string `json:"direction"`
+	Commands  string `json:"commands"`
+}
+
+// instructions converts the request into an instruction-set.
+func (m *MissionRequest) instructions() string {
+	if m.Surface == nil {
+		return m.Instructions
+	}
+	lines := []string{fmt.Sprintf("%d %d %d %d", m.Surface.LowerX, m.Surface.LowerY, m.Surface.X, m.Surface.Y)}
+	for _, r := range m.Robots {
+		lines = append(lines, fmt.Sprintf("%d %d %s", r.X, r.Y, r.Direction), r.Commands)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// motion converts the request's motion model, if it has one.
+func (m *MissionRequest) motion() *runner.Motion {
+	if m.Motion == nil {
+		return nil
+	}
+	return &runner.Motion{Seed: m.Motion.Seed, Stall: m.Motion.Stall, Overshoot: m.Motion.Overshoot, Drift: m.Motion.Drift, OverRotate: m.Motion.OverRotate}
+}
+
+// MissionResponse is the JSON representation of the outcome of a mission.
+// Output holds the final resting positions in the same format as runner.Run.
+type MissionResponse struct {
+	Robots []RobotResponse `json:"robots"`
+	Output string          `json:"output"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// RobotResponse holds where a robot finished and whether it carried out all of its commands.
+// Plateau is the name of the plateau the robot finished upon whenever the mission spans several,
+// and Type is the name of the robot's type of rover, if it has one.
+type RobotResponse struct {
+	ID        int    `json:"id"`
+	Type      string `json:"type,omitempty"`
+	Plateau   string `json:"plateau,omitempty"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Direction string `json:"direction"`
+	Steps     int    `json:"steps"`
+	Done      bool   `json:"done"`
+}
+
+// server handles requests to run missions.
+type server struct {
+	opts *Options
+}
+
+// New creates an http.Handler that serves the following:
+//
+// POST /missions runs a mission sent either as text/plain in the same format as runner.Run,
+// or as application/json in the form of a MissionRequest, and responds with a MissionResponse.
+//
+// GET /healthz responds with a 200 whenever the service is able to run missions.
+//
+// GET /metrics responds with the metrics recorded for every mission in the Prometheus text
+// format, whenever Metrics has been given.
+func New(opts *Options) http.Handler {
+	if opts == nil {
+		opts = &Options{}
+	}
+	s := &server{opts: opts}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/missions", s.handleMission)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	if opts.Metrics != nil {
+		mux.Handle("/metrics", opts.Metrics)
+	}
+	return mux
+}
+
+// handleMission runs the mission within the request.
+func (s *server) handleMission(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, &Error{Code: CodeMethodNotAllowed, Message: "missions must be sent via POST"})
+		return
+	}
+	maxBytes := s.opts.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBodyBytes
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, &Error{Code: CodeInvalidRequest, Message: err.Error()})
+		return
+	}
+
+	req := &MissionRequest{Instructions: string(body)}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		req = &MissionRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			writeError(w, http.StatusBadRequest, &Error{Code: CodeInvalidRequest, Message: err.Error()})
+			return
+		}
+	case "text/plain", "":
+	default:
+		writeError(w, http.StatusUnsupportedMediaType, &Error{Code: CodeUnsupportedMediaType, Message: fmt.Sprintf("'%s' is not supported - use text/plain or application/json", mediaType)})
+		return
+	}
+
+	ctx := r.Context()
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+	mission, err := s.opts.Metrics.Simulate(ctx, req.instructions(), &runner.Options{
+		Simultaneous: req.Simultaneous,
+		Grid:         runner.Grid(req.Grid),
+		Speed:        req.Speed,
+		RobotSpeeds:  req.RobotSpeeds,
+		Motion:       req.motion(),
+		Limits:       s.opts.Limits,
+		Logger:       s.opts.Logger,
+	})
+	resp := &MissionResponse{Robots: []RobotResponse{}}
+	if mission != nil {
+		resp.Output = mission.String()
+		for _, t := range mission.Tracks {
+			end := t.End()
+			resp.Robots = append(resp.Robots, RobotResponse{
+				ID:        t.ID,
+				Type:      t.Type,
+				Plateau:   end.Plateau,
+				X:         end.X,
+				Y:         end.Y,
+				Direction: string(end.Direction),
+				Steps:     len(t.Steps),
+				Done:      t.Done,
+			})
+		}
+	}
+	status := http.StatusOK
+	if err != nil {
+		status, resp.Error = classify(err)
+	}
+	writeJSON(w, status, resp)
+}
+
+// writeError writes a response that only holds an error.
+func writeError(w http.ResponseWriter, status int, e *Error) {
+	writeJSON(w, status, &MissionResponse{Robots: []RobotResponse{}, Error: e})
+}
+
+// writeJSON writes v as the JSON body of the response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/juubisnake/mars-rover/pkg/metrics"
+	"github.com/juubisnake/mars-rover/pkg/runner"
+)
+
+func testRequest(t *testing.T, h http.Handler, method, contentType, body string, expectedStatus int) *MissionResponse {
+	req := httptest.NewRequest(method, "/missions", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != expectedStatus {
+		t.Fatalf("expected status %d - got %d instead: %s", expectedStatus, rec.Code, rec.Body.String())
+	}
+	resp := &MissionResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+		t.Fatalf("expected a JSON response - got %s instead", rec.Body.String())
+	}
+	return resp
+}
+
+func TestMission_Text(t *testing.T) {
+	resp := testRequest(t, New(nil), http.MethodPost, "text/plain", "5 5\n1 2 N\nLMLMLMLMM\n3 3 E\nMMRMMRMRRM", http.StatusOK)
+	if resp.Output != "1 3 N\n5 1 E" || resp.Error != nil {
+		t.Fatalf("expected the final resting positions - got %s instead", resp.Output)
+	}
+	expected := RobotResponse{ID: 2, X: 5, Y: 1, Direction: "E", Steps: 10, Done: true}
+	if len(resp.Robots) != 2 || resp.Robots[1] != expected {
+		t.Fatalf("expected robot ID 2 to be %v - got %v instead", expected, resp.Robots)
+	}
+	resp = testRequest(t, New(nil), http.MethodPost, "text/plain", "5 5\ntype scout 2 LRM avoid 1s\n1 1 N scout\nM", http.StatusOK)
+	expected = RobotResponse{ID: 0, Type: "scout", X: 1, Y: 3, Direction: "N", Steps: 1, Done: true}
+	if len(resp.Robots) != 1 || resp.Robots[0] != expected {
+		t.Fatalf("expected robot ID 0 to be %v - got %v instead", expected, resp.Robots)
+	}
+}
+
+func TestMission_Plateaus(t *testing.T) {
+	resp := testRequest(t, New(nil), http.MethodPost, "text/plain", "plateau alpha 5 5\nplateau beta 3 3\nbeta 1 1 N\nM\nalpha 0 0 E\nM", http.StatusOK)
+	expected := []RobotResponse{
+		{ID: 0, Plateau: "beta", X: 1, Y: 2, Direction: "N", Steps: 1, Done: true},
+		{ID: 2, Plateau: "alpha", X: 1, Y: 0, Direction: "E", Steps: 1, Done: true},
+	}
+	if !reflect.DeepEqual(resp.Robots, expected) {
+		t.Fatalf("expected each robot to report its plateau %v - got %v instead", expected, resp.Robots)
+	}
+}
+
+func TestMission_JSON(t *testing.T) {
+	body := `{"surface":{"x":5,"y":5},"robots":[{"x":1,"y":2,"direction":"N","commands":"LMLMLMLMM"},{"x":3,"y":3,"direction":"E","commands":"MMRMMRMRRM"}]}`
+	resp := testRequest(t, New(nil), http.MethodPost, "application/json", body, http.StatusOK)
+	if resp.Output != "1 3 N\n5 1 E" {
+		t.Fatalf("expected the final resting positions - got %s instead", resp.Output)
+	}
+	body = `{"surface":{"lower_x":-5,"lower_y":-5,"x":5,"y":5},"robots":[{"x":-5,"y":-5,"direction":"E","commands":"MM"}]}`
+	resp = testRequest(t, New(nil), http.MethodPost, "application/json", body, http.StatusOK)
+	if resp.Output != "-3 -5 E" {
+		t.Fatalf("expected the final resting position upon a surface with lower bounds - got %s instead", resp.Output)
+	}
+	body = `{"instructions":"5 5\n1 2 N\nLMLMLMLMM"}`
+	resp = testRequest(t, New(nil), http.MethodPost, "application/json; charset=utf-8", body, http.StatusOK)
+	if resp.Output != "1 3 N" {
+		t.Fatalf("expected the final resting positions - got %s instead", resp.Output)
+	}
+}
+
+func TestMission_Errors(t *testing.T) {
+	h := New(&Options{Limits: &runner.Limits{MaxRobots: 1}})
+	tests := []struct {
+		body    string
+		status  int
+		code    string
+		robotID *int
+	}{
+		{"5 5", http.StatusBadRequest, CodeMissingInputLines, nil},
+		{"5 5\n1 2 F\nM", http.StatusBadRequest, CodeParseRobotDirection, new(int)},
+		{"5 5\n1 2 N\nMX", http.StatusBadRequest, CodeParseRobotMovement, new(int)},
+		{"5 5\n1 2 N\nMMMM", http.StatusUnprocessableEntity, CodeRobotOutOfBounds, new(int)},
+		{"plateau alpha 5 5\nportal alpha 1\nalpha 1 1 N\nM", http.StatusBadRequest, CodePlateauInstruction, nil},
+		{"plateau alpha 5 5\nbeta 1 1 N\nM", http.StatusBadRequest, CodeUnknownPlateau, new(int)},
+		{"5 5\ntype scout 0 M avoid 1s\n1 1 N\nM", http.StatusBadRequest, CodeDeclaration, nil},
+		{"5 5\ntype scout 1 M avoid 1s\n1 1 N rover\nM", http.StatusBadRequest, CodeUnknownType, new(int)},
+		{"5 5\ntype scout 1 M avoid 1s\n1 1 N scout\nL", http.StatusUnprocessableEntity, CodeRobotCommand, new(int)},
+		{"5 5\nobstacle 1 2\n1 1 N\nM", http.StatusUnprocessableEntity, CodeRobotObstacle, new(int)},
+		{"5 5\n1 2 N\nM\n1 2 N\nM", http.StatusRequestEntityTooLarge, CodeLimitExceeded, nil},
+	}
+	for _, test := range tests {
+		resp := testRequest(t, h, http.MethodPost, "text/plain", test.body, test.status)
+		if resp.Error == nil || resp.Error.Code != test.code {
+			t.Fatalf("expected %q to fail with %s - got %v instead", test.body, test.code, resp.Error)
+		}
+		if (test.robotID == nil) != (resp.Error.RobotID == nil) {
+			t.Fatalf("expected %q to have robot ID %v - got %v instead", test.body, test.robotID, resp.Error.RobotID)
+		}
+	}
+	resp := testRequest(t, h, http.MethodPost, "text/plain", "5 5\n1 2 N\nM\n1 2 N\nM", http.StatusRequestEntityTooLarge)
+	if resp.Error.Limit != runner.RobotsLimit {
+		t.Fatalf("expected the robots limit to have been exceeded - got %s instead", resp.Error.Limit)
+	}
+}
+
+func TestMission_Options(t *testing.T) {
+	tests := []struct {
+		body    string
+		status  int
+		output  string
+		code    string
+		robotID *int
+	}{
+		{`{"instructions":"2\n0 0 E\nM","grid":"hex"}`, http.StatusOK, "1 0 E", "", nil},
+		{`{"instructions":"5 5\n1 2 N\nM","grid":"triangle"}`, http.StatusBadRequest, "", CodeUnknownGrid, nil},
+		{`{"instructions":"5 5\n1 1 N\nM\n2 1 N\nM","speed":2,"robot_speeds":{"2":3}}`, http.StatusOK, "1 3 N\n2 4 N", "", nil},
+		{`{"instructions":"5 5\n1 2 N\nM","speed":-1}`, http.StatusBadRequest, "", CodeInvalidSpeed, nil},
+		{`{"instructions":"5 5\n1 2 N\nM","robot_speeds":{"0":0}}`, http.StatusBadRequest, "", CodeInvalidSpeed, new(int)},
+		{`{"instructions":"5 5\n1 2 N\nMM","motion":{"seed":1,"stall":1}}`, http.StatusOK, "1 2 N", "", nil},
+		{`{"instructions":"5 5\n1 2 N\nM","motion":{"stall":1.5}}`, http.StatusBadRequest, "", CodeInvalidMotion, nil},
+	}
+	for _, test := range tests {
+		resp := testRequest(t, New(nil), http.MethodPost, "application/json", test.body, test.status)
+		if resp.Output != test.output {
+			t.Fatalf("expected %s to output %q - got %q instead", test.body, test.output, resp.Output)
+		}
+		if test.code == "" {
+			if resp.Error != nil {
+				t.Fatalf("expected %s to succeed - got %v instead", test.body, resp.Error)
+			}
+			continue
+		}
+		if resp.Error == nil || resp.Error.Code != test.code {
+			t.Fatalf("expected %s to fail with %s - got %v instead", test.body, test.code, resp.Error)
+		}
+		if !reflect.DeepEqual(resp.Error.RobotID, test.robotID) {
+			t.Fatalf("expected %s to have robot ID %v - got %v instead", test.body, test.robotID, resp.Error.RobotID)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	two := 2
+	tests := []struct {
+		err     error
+		status  int
+		code    string
+		robotID *int
+	}{
+		{&runner.RobotInputLinesError{Lines: 1}, http.StatusBadRequest, CodeRobotInputLines, nil},
+		{&runner.RobotClimbError{ID: 2, Climb: 3, Max: 1}, http.StatusUnprocessableEntity, CodeRobotClimb, &two},
+	}
+	for _, test := range tests {
+		status, e := classify(test.err)
+		if status != test.status || e.Code != test.code {
+			t.Fatalf("expected %T to be a %d with %s - got a %d with %s instead", test.err, test.status, test.code, status, e.Code)
+		}
+		if !reflect.DeepEqual(e.RobotID, test.robotID) {
+			t.Fatalf("expected %T to have robot ID %v - got %v instead", test.err, test.robotID, e.RobotID)
+		}
+	}
+}
+
+func TestMission_PartialResults(t *testing.T) {
+	resp := testRequest(t, New(nil), http.MethodPost, "", "5 5\n1 2 N\nLMLMLMLMM\n3 3 E\nMMM", http.StatusUnprocessableEntity)
+	if resp.Output != "1 3 N" || len(resp.Robots) != 2 || resp.Robots[1].Done || resp.Robots[1].X != 6 {
+		t.Fatalf("expected the partial results of the mission - got %v instead", resp)
+	}
+}
+
+func TestMission_Timeout(t *testing.T) {
+	h := New(&Options{Timeout: time.Nanosecond})
+	resp := testRequest(t, h, http.MethodPost, "text/plain", "5 5\n1 2 N\n"+strings.Repeat("L", 1<<16), http.StatusServiceUnavailable)
+	if resp.Error.Code != CodeCancelled {
+		t.Fatalf("expected the mission to be cancelled - got %v instead", resp.Error)
+	}
+}
+
+func TestMission_InvalidRequests(t *testing.T) {
+	h := New(&Options{MaxBodyBytes: 16})
+	testRequest(t, h, http.MethodGet, "", "", http.StatusMethodNotAllowed)
+	testRequest(t, h, http.MethodPost, "application/json", "{", http.StatusBadRequest)
+	testRequest(t, h, http.MethodPost, "application/xml", "<mission/>", http.StatusUnsupportedMediaType)
+	testRequest(t, h, http.MethodPost, "text/plain", strings.Repeat("5", 17), http.StatusRequestEntityTooLarge)
+}
+
+func TestHealthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200 - got %d instead", rec.Code)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected no metrics without a registry - got status %d instead", rec.Code)
+	}
+	h := New(&Options{Metrics: metrics.New()})
+	testRequest(t, h, http.MethodPost, "text/plain", "5 5\n0 0 S\nM", http.StatusUnprocessableEntity)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), `mars_rover_mission_failures_total{error="RobotOutOfBoundsError"} 1`) {
+		t.Fatalf("expected the failed mission to be recorded - got %s instead", rec.Body.String())
+	}
+}